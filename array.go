@@ -0,0 +1,155 @@
+package lit
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// pgArrayField wraps a slice field stored in a PostgreSQL array column
+// (text[], integer[], bigint[], ...). A nil slice is stored as NULL.
+type pgArrayField struct {
+	field reflect.Value
+}
+
+var pgArrayQuoter = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+func (a pgArrayField) Value() (driver.Value, error) {
+	if a.field.IsNil() {
+		return nil, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i := 0; i < a.field.Len(); i++ {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		elem := a.field.Index(i)
+		switch elem.Kind() {
+		case reflect.String:
+			sb.WriteByte('"')
+			sb.WriteString(pgArrayQuoter.Replace(elem.String()))
+			sb.WriteByte('"')
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			sb.WriteString(strconv.FormatInt(elem.Int(), 10))
+		case reflect.Float32, reflect.Float64:
+			sb.WriteString(strconv.FormatFloat(elem.Float(), 'g', -1, 64))
+		case reflect.Bool:
+			sb.WriteString(strconv.FormatBool(elem.Bool()))
+		}
+	}
+	sb.WriteByte('}')
+	return sb.String(), nil
+}
+
+func (a pgArrayField) Scan(src any) error {
+	var text string
+	switch v := src.(type) {
+	case nil:
+		a.field.SetZero()
+		return nil
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	default:
+		return fmt.Errorf("cannot scan %T into array column", src)
+	}
+
+	elems, err := parsePgArray(text)
+	if err != nil {
+		return err
+	}
+
+	slice := reflect.MakeSlice(a.field.Type(), len(elems), len(elems))
+	for i, elem := range elems {
+		if !elem.Valid {
+			continue
+		}
+		if err := assignScanned(slice.Index(i), elem.String); err != nil {
+			return err
+		}
+	}
+	a.field.Set(slice)
+	return nil
+}
+
+// parsePgArray splits a one-dimensional PostgreSQL array literal such as
+// {a,"b c",NULL} into its elements. NULL elements are returned invalid and
+// scan as the element type's zero value.
+func parsePgArray(text string) ([]sql.NullString, error) {
+	if len(text) < 2 || text[0] != '{' || text[len(text)-1] != '}' {
+		return nil, fmt.Errorf("invalid array literal: %s", text)
+	}
+	body := text[1 : len(text)-1]
+	elems := []sql.NullString{}
+	if body == "" {
+		return elems, nil
+	}
+
+	for i := 0; i <= len(body); {
+		for i < len(body) && body[i] == ' ' {
+			i++
+		}
+		if i < len(body) && body[i] == '{' {
+			return nil, errors.New("multi-dimensional arrays are not supported")
+		}
+
+		var elem strings.Builder
+		if i < len(body) && body[i] == '"' {
+			i++
+			for i < len(body) && body[i] != '"' {
+				if body[i] == '\\' && i+1 < len(body) {
+					i++
+				}
+				elem.WriteByte(body[i])
+				i++
+			}
+			if i >= len(body) {
+				return nil, fmt.Errorf("unterminated quoted element in array literal: %s", text)
+			}
+			i++
+			for i < len(body) && body[i] == ' ' {
+				i++
+			}
+			elems = append(elems, sql.NullString{String: elem.String(), Valid: true})
+		} else {
+			end := strings.IndexByte(body[i:], ',')
+			if end < 0 {
+				end = len(body) - i
+			}
+			raw := strings.TrimSpace(body[i : i+end])
+			elems = append(elems, sql.NullString{String: raw, Valid: raw != "NULL"})
+			i += end
+		}
+
+		if i < len(body) && body[i] != ',' {
+			return nil, fmt.Errorf("invalid array literal: %s", text)
+		}
+		i++
+	}
+	return elems, nil
+}
+
+// usesPgArrays reports whether slice fields map to native array columns.
+func usesPgArrays(driver Driver) bool {
+	switch driver.(type) {
+	case *pgDriver, *cockroachDriver:
+		return true
+	}
+	return false
+}
+
+func isArrayElemKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Float32, reflect.Float64, reflect.Bool:
+		return true
+	}
+	return false
+}
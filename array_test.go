@@ -0,0 +1,139 @@
+package lit
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type TestTaggedPost struct {
+	Id     int
+	Tags   []string
+	Scores []int
+	Views  []int64 `lit:"views,array"`
+}
+
+func TestRegisterModel_ArrayFields(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestTaggedPost]())
+	RegisterModel[TestTaggedPost](PostgreSQL)
+
+	fieldMap, err := GetFieldMap(reflect.TypeFor[TestTaggedPost]())
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, fieldMap.ArrayFields)
+	assert.Empty(t, fieldMap.JSONFields)
+}
+
+func TestRegisterModel_ArrayFieldsFallBackToJSON(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestTaggedPost]())
+	RegisterModel[TestTaggedPost](MySQL)
+
+	fieldMap, err := GetFieldMap(reflect.TypeFor[TestTaggedPost]())
+	require.NoError(t, err)
+	assert.Empty(t, fieldMap.ArrayFields)
+	assert.Equal(t, []int{3}, fieldMap.JSONFields)
+}
+
+func TestRegisterModel_ArrayOptionRequiresSlice(t *testing.T) {
+	type BadArray struct {
+		Id   int
+		Tags string `lit:"tags,array"`
+	}
+	assert.Panics(t, func() { RegisterModel[BadArray](PostgreSQL) })
+}
+
+func TestArrayFields_RoundTrip(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestTaggedPost]())
+	RegisterModel[TestTaggedPost](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("INSERT INTO test_tagged_posts").
+		WithArgs(`{"go","say \"hi\"","a,b"}`, "{1,-2,3}", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tags", "scores", "views"}).
+			AddRow(1, []byte(`{go,"say \"hi\"","a,b",NULL}`), "{1,-2,3}", "{}"))
+
+	post := &TestTaggedPost{
+		Tags:   []string{"go", `say "hi"`, "a,b"},
+		Scores: []int{1, -2, 3},
+	}
+	_, err = Insert(db, post)
+	require.NoError(t, err)
+
+	loaded, err := SelectSingle[TestTaggedPost](db, "SELECT * FROM test_tagged_posts")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go", `say "hi"`, "a,b", ""}, loaded.Tags)
+	assert.Equal(t, []int{1, -2, 3}, loaded.Scores)
+	assert.Equal(t, []int64{}, loaded.Views)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArrayFields_MySQLUsesJSON(t *testing.T) {
+	type TestViewCounter struct {
+		Id    int
+		Views []int64 `lit:"views,array"`
+	}
+	RegisterModel[TestViewCounter](MySQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO test_view_counters").
+		WithArgs(driver.Value("[7,8]")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	_, err = Insert(db, &TestViewCounter{Views: []int64{7, 8}})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArrayFields_NullElements(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestTaggedPost]())
+	RegisterModel[TestTaggedPost](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tags", "scores", "views"}).
+			AddRow(1, "{a,NULL}", "{1,NULL,3}", []byte("{NULL,5}")))
+
+	loaded, err := SelectSingle[TestTaggedPost](db, "SELECT * FROM test_tagged_posts")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", ""}, loaded.Tags)
+	assert.Equal(t, []int{1, 0, 3}, loaded.Scores)
+	assert.Equal(t, []int64{0, 5}, loaded.Views)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParsePgArray(t *testing.T) {
+	elems, err := parsePgArray(`{a, "b\\c" ,NULL}`)
+	require.NoError(t, err)
+	assert.Equal(t, []sql.NullString{
+		{String: "a", Valid: true},
+		{String: `b\c`, Valid: true},
+		{String: "NULL"},
+	}, elems)
+
+	elems, err = parsePgArray(`{"NULL"}`)
+	require.NoError(t, err)
+	assert.Equal(t, []sql.NullString{{String: "NULL", Valid: true}}, elems)
+
+	_, err = parsePgArray("{{1,2},{3,4}}")
+	assert.Error(t, err)
+
+	_, err = parsePgArray(`{"open`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,120 @@
+package lit
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// QueryBuilder builds a select of T whose conditions are only known at
+// runtime:
+//
+//	q := lit.NewQueryBuilder[User]().Where("team_id = $1", teamId)
+//	if search != "" {
+//		q.Where("name LIKE $1", search+"%")
+//	}
+//	users, err := q.OrderBy("created_at", "desc").Limit(20).Select(db)
+//
+// Each Where numbers its placeholders from 1; they are renumbered with the
+// driver's RenumberWhereClause when the query is built. The zero value is
+// ready to use.
+type QueryBuilder[T any] struct {
+	wheres   []string
+	args     [][]any
+	orders   []string
+	limit    int
+	hasLimit bool
+	offset   int
+}
+
+// NewQueryBuilder returns an empty QueryBuilder for T.
+func NewQueryBuilder[T any]() *QueryBuilder[T] {
+	return &QueryBuilder[T]{}
+}
+
+// Where adds a condition, ANDed with the others.
+func (q *QueryBuilder[T]) Where(cond string, args ...any) *QueryBuilder[T] {
+	q.wheres = append(q.wheres, cond)
+	q.args = append(q.args, args)
+	return q
+}
+
+// OrderBy adds a sort column, validated against the model when the query is
+// built. dir is "asc" or "desc". Without OrderBy the model's default order is
+// used.
+func (q *QueryBuilder[T]) OrderBy(col string, dir string) *QueryBuilder[T] {
+	q.orders = append(q.orders, col+"."+dir)
+	return q
+}
+
+// Limit caps the number of rows returned.
+func (q *QueryBuilder[T]) Limit(n int) *QueryBuilder[T] {
+	q.limit = n
+	q.hasLimit = true
+	return q
+}
+
+// Offset skips the first n rows. MySQL and SQLite only accept it together
+// with Limit.
+func (q *QueryBuilder[T]) Offset(n int) *QueryBuilder[T] {
+	q.offset = n
+	return q
+}
+
+// Select runs the query and returns every matching row.
+func (q *QueryBuilder[T]) Select(ex Executor) ([]*T, error) {
+	query, args, err := q.build(ex)
+	if err != nil {
+		return nil, err
+	}
+	return Select[T](ex, query, args...)
+}
+
+// SelectSingle runs the query and returns the first row, or nil, nil when
+// there is none, like SelectSingle.
+func (q *QueryBuilder[T]) SelectSingle(ex Executor) (*T, error) {
+	query, args, err := q.build(ex)
+	if err != nil {
+		return nil, err
+	}
+	return SelectSingle[T](ex, query, args...)
+}
+
+func (q *QueryBuilder[T]) build(ex Executor) (string, []any, error) {
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return "", nil, err
+	}
+	if q.hasLimit && q.limit < 0 {
+		return "", nil, fmt.Errorf("limit must not be negative, got %d", q.limit)
+	}
+	if q.offset < 0 {
+		return "", nil, fmt.Errorf("offset must not be negative, got %d", q.offset)
+	}
+
+	conds := make([]string, len(q.wheres))
+	args := []any{}
+	for i, cond := range q.wheres {
+		conds[i] = "(" + fieldMap.Driver.RenumberWhereClause(trimWhereKeyword(cond), len(args)) + ")"
+		args = append(args, q.args[i]...)
+	}
+
+	opts := []SelectOption{}
+	if len(q.orders) > 0 {
+		opts = append(opts, OrderBy(strings.Join(q.orders, ",")))
+	}
+	query, err := buildSelectQuery(ex, fieldMap, strings.Join(conds, " AND "), opts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	limit, offset := -1, -1
+	if q.hasLimit {
+		limit = q.limit
+	}
+	if q.offset > 0 {
+		offset = q.offset
+	}
+	paging, args := pagingClause(fieldMap.Driver, args, limit, offset)
+	return query + paging, args, nil
+}
@@ -0,0 +1,117 @@
+package lit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryBuilder_PostgreSQL(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id,first_name,last_name,email FROM test_users WHERE (last_name = $1) AND (first_name = $2 OR email = $3) ORDER BY email DESC,id ASC LIMIT $4 OFFSET $5").
+		WithArgs("Doe", "John", "john@example.com", 10, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "email"}).
+			AddRow(1, "John", "Doe", "john@example.com"))
+
+	users, err := NewQueryBuilder[TestUser]().
+		Where("last_name = $1", "Doe").
+		Where("WHERE first_name = $1 OR email = $2", "John", "john@example.com").
+		OrderBy("email", "desc").
+		OrderBy("id", "asc").
+		Limit(10).
+		Offset(20).
+		Select(db)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "John", users[0].FirstName)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryBuilder_MySQL(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](MySQL)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id,first_name,last_name,email FROM test_users WHERE (last_name = ?) AND (email LIKE ?) LIMIT ?").
+		WithArgs("Doe", "%@example.com", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "email"}).
+			AddRow(2, "Jane", "Doe", "jane@example.com"))
+
+	var q QueryBuilder[TestUser]
+	q.Where("last_name = ?", "Doe")
+	q.Where("email LIKE ?", "%@example.com")
+	user, err := q.Limit(1).SelectSingle(db)
+	require.NoError(t, err)
+	require.NotNil(t, user)
+	assert.Equal(t, "Jane", user.FirstName)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryBuilder_NoClauses(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](SQLite)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id,first_name,last_name,email FROM test_users").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "email"}))
+
+	users, err := NewQueryBuilder[TestUser]().Select(db)
+	require.NoError(t, err)
+	assert.Empty(t, users)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryBuilder_DefaultOrder(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestOrderedPost]())
+	RegisterModelWithOptions[TestOrderedPost](PostgreSQL, WithDefaultOrder("created_at.desc"))
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT id,title,created_at,"order" FROM test_ordered_posts WHERE (title <> $1) ORDER BY created_at DESC LIMIT $2`).
+		WithArgs("", 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(`SELECT id,title,created_at,"order" FROM test_ordered_posts ORDER BY title ASC`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err = NewQueryBuilder[TestOrderedPost]().Where("title <> $1", "").Limit(5).Select(db)
+	require.NoError(t, err)
+	_, err = NewQueryBuilder[TestOrderedPost]().OrderBy("title", "asc").Select(db)
+	require.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryBuilder_Errors(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+
+	_, err := NewQueryBuilder[TestUser]().OrderBy("missing", "asc").Select(nil)
+	assert.EqualError(t, err, "invalid order column that is not found in the struct: missing")
+
+	_, err = NewQueryBuilder[TestUser]().OrderBy("id", "up").Select(nil)
+	assert.EqualError(t, err, `invalid order direction "up" for column id`)
+
+	_, err = NewQueryBuilder[TestUser]().Limit(-1).Select(nil)
+	assert.EqualError(t, err, "limit must not be negative, got -1")
+
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	_, err = NewQueryBuilder[TestUser]().Select(nil)
+	assert.ErrorAs(t, err, &NotRegisteredError{})
+}
@@ -0,0 +1,370 @@
+// Command lit-gen generates reflection-free scanning code for the models a
+// package registers with lit. Add to a package:
+//
+//	//go:generate lit-gen .
+//
+// For every struct T used in a lit.RegisterModel*[T] call, lit_generated.go
+// gets a scanT(rows *sql.Rows, t *T) error function, registered in init with
+// lit.RegisterScanner, and a lit.RegisterFieldPointers[T] accessor for
+// queries selecting other columns, so scanning and binding take field
+// addresses directly instead of through reflection. The FieldMap itself is
+// still built by RegisterModel, since table and column names, the driver and
+// the model options are only known there. Pass ./... to process all packages
+// below a directory.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	litImportPath = "github.com/tracewayapp/lit/v2"
+	outputFile    = "lit_generated.go"
+)
+
+func main() {
+	patterns := os.Args[1:]
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	var dirs []string
+	for _, pattern := range patterns {
+		found, err := expandPattern(pattern)
+		if err != nil {
+			fail(err)
+		}
+		dirs = append(dirs, found...)
+	}
+
+	for _, dir := range dirs {
+		source, err := generateDir(dir)
+		if err != nil {
+			fail(err)
+		}
+		if source == nil {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(dir, outputFile), source, 0o644); err != nil {
+			fail(err)
+		}
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "lit-gen:", err)
+	os.Exit(1)
+}
+
+// expandPattern turns "dir" into itself and "dir/..." into dir and every
+// directory below it, skipping testdata, vendor and hidden directories.
+func expandPattern(pattern string) ([]string, error) {
+	root, recursive := strings.CutSuffix(pattern, "/...")
+	if !recursive {
+		return []string{pattern}, nil
+	}
+	if root == "" {
+		root = "."
+	}
+
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		name := d.Name()
+		if path != root && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+			return filepath.SkipDir
+		}
+		dirs = append(dirs, path)
+		return nil
+	})
+	return dirs, err
+}
+
+// generateDir returns the generated file for the package in dir, or nil when
+// it registers no local models.
+func generateDir(dir string) ([]byte, error) {
+	fset := token.NewFileSet()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == outputFile {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	structs := map[string]*ast.StructType{}
+	models := map[string]bool{}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if st, ok := typeSpec.Type.(*ast.StructType); ok && typeSpec.TypeParams == nil {
+					structs[typeSpec.Name.Name] = st
+				}
+			}
+		}
+
+		litName := litImportName(file)
+		if litName == "" {
+			continue
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			if name := registeredModel(n, litName); name != "" {
+				models[name] = true
+			}
+			return true
+		})
+	}
+
+	var names []string
+	for name := range models {
+		if _, ok := structs[name]; ok {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	slices.Sort(names)
+
+	return render(files[0].Name.Name, names, structs)
+}
+
+// litImportName returns the name lit is imported under in file, or "".
+func litImportName(file *ast.File) string {
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || path != litImportPath {
+			continue
+		}
+		if spec.Name != nil {
+			return spec.Name.Name
+		}
+		return "lit"
+	}
+	return ""
+}
+
+// registeredModel returns T when n is a call to lit.RegisterModel*[T], where T
+// is declared in the same package.
+func registeredModel(n ast.Node, litName string) string {
+	call, ok := n.(*ast.CallExpr)
+	if !ok {
+		return ""
+	}
+	var fun, typeArg ast.Expr
+	switch index := call.Fun.(type) {
+	case *ast.IndexExpr:
+		fun, typeArg = index.X, index.Index
+	case *ast.IndexListExpr:
+		fun, typeArg = index.X, index.Indices[0]
+	default:
+		return ""
+	}
+	selector, ok := fun.(*ast.SelectorExpr)
+	if !ok || !strings.HasPrefix(selector.Sel.Name, "RegisterModel") {
+		return ""
+	}
+	if pkg, ok := selector.X.(*ast.Ident); !ok || pkg.Name != litName {
+		return ""
+	}
+	if ident, ok := typeArg.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+func render(pkg string, names []string, structs map[string]*ast.StructType) ([]byte, error) {
+	var b bytes.Buffer
+	scanned := map[string][]scanField{}
+	b.WriteString("func init() {\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "\tlit.RegisterFieldPointers(func(t *%s, field int) any {\n", name)
+		b.WriteString("\t\tswitch field {\n")
+		index := 0
+		for _, field := range structs[name].Fields.List {
+			if len(field.Names) == 0 {
+				// Embedded fields are flattened by lit and left to reflection.
+				index++
+				continue
+			}
+			for _, ident := range field.Names {
+				if ident.IsExported() {
+					fmt.Fprintf(&b, "\t\tcase %d:\n\t\t\treturn &t.%s\n", index, ident.Name)
+				}
+				index++
+			}
+		}
+		b.WriteString("\t\t}\n\t\treturn nil\n\t})\n")
+
+		fields, ok := scanFields(structs[name], structs, nil, nil)
+		if !ok || len(fields) == 0 {
+			continue
+		}
+		scanned[name] = fields
+		fmt.Fprintf(&b, "\tlit.RegisterScanner(scan%s", name)
+		for _, field := range fields {
+			b.WriteString(", []int{")
+			for i, n := range field.index {
+				if i > 0 {
+					b.WriteString(", ")
+				}
+				b.WriteString(strconv.Itoa(n))
+			}
+			b.WriteString("}")
+		}
+		b.WriteString(")\n")
+	}
+	b.WriteString("}\n")
+
+	for _, name := range names {
+		fields, ok := scanned[name]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&b, "\nfunc scan%s(rows *sql.Rows, t *%s) error {\n\treturn rows.Scan(", name, name)
+		for i, field := range fields {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString("&t." + strings.Join(field.path, "."))
+		}
+		b.WriteString(")\n}\n")
+	}
+
+	var header bytes.Buffer
+	header.WriteString("// Code generated by lit-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&header, "package %s\n\n", pkg)
+	if len(scanned) > 0 {
+		fmt.Fprintf(&header, "import (\n\t\"database/sql\"\n\n\t%q\n)\n\n", litImportPath)
+	} else {
+		fmt.Fprintf(&header, "import %q\n\n", litImportPath)
+	}
+	return format.Source(append(header.Bytes(), b.Bytes()...))
+}
+
+// scanField is a column of a model: the index chain lit stores for it and the
+// selector path reaching it from the model.
+type scanField struct {
+	index []int
+	path  []string
+}
+
+// scanFields lists the columns of st the way lit flattens it, embedded
+// structs declared in the package expanded in place and a shallower field
+// shadowing a deeper one of the same name. It reports false when st embeds a
+// type it can't see into, whose columns would then be unknown.
+func scanFields(st *ast.StructType, structs map[string]*ast.StructType, index []int, path []string) ([]scanField, bool) {
+	var fields []scanField
+	positions := map[string]int{}
+	i := 0
+	for _, field := range st.Fields.List {
+		names := field.Names
+		if len(names) == 0 {
+			name, ok := embeddedName(field.Type)
+			if !ok {
+				return nil, false
+			}
+			names = []*ast.Ident{ast.NewIdent(name)}
+		}
+		for _, ident := range names {
+			fieldIndex := append(slices.Clone(index), i)
+			fieldPath := append(slices.Clone(path), ident.Name)
+			i++
+
+			candidates := []scanField{{fieldIndex, fieldPath}}
+			if len(field.Names) == 0 && !hasLitTag(field) {
+				if ident, ok := field.Type.(*ast.Ident); ok {
+					if embedded, ok := structs[ident.Name]; ok {
+						nested, ok := scanFields(embedded, structs, fieldIndex, fieldPath)
+						if !ok {
+							return nil, false
+						}
+						candidates = nested
+					}
+				} else if _, ok := field.Type.(*ast.SelectorExpr); ok && !isTimeType(field.Type) {
+					// A struct from another package would be flattened too.
+					return nil, false
+				}
+			}
+			for _, candidate := range candidates {
+				name := candidate.path[len(candidate.path)-1]
+				if pos, ok := positions[name]; ok {
+					if len(candidate.index) < len(fields[pos].index) {
+						fields[pos] = candidate
+					}
+					continue
+				}
+				positions[name] = len(fields)
+				fields = append(fields, candidate)
+			}
+		}
+	}
+	return fields, true
+}
+
+// embeddedName returns the field name of an embedded type, T or *T, either
+// local or package qualified.
+func embeddedName(expr ast.Expr) (string, bool) {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	switch typ := expr.(type) {
+	case *ast.Ident:
+		return typ.Name, true
+	case *ast.SelectorExpr:
+		return typ.Sel.Name, true
+	}
+	return "", false
+}
+
+func hasLitTag(field *ast.Field) bool {
+	if field.Tag == nil {
+		return false
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	return err == nil && reflect.StructTag(tag).Get("lit") != ""
+}
+
+func isTimeType(expr ast.Expr) bool {
+	selector, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := selector.X.(*ast.Ident)
+	return ok && pkg.Name == "time" && selector.Sel.Name == "Time"
+}
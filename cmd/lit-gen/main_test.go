@@ -0,0 +1,155 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const modelsSource = `package models
+
+import (
+	"time"
+
+	l "github.com/tracewayapp/lit/v2"
+)
+
+type Base struct {
+	CreatedAt time.Time
+}
+
+type User struct {
+	Id                  int
+	FirstName, LastName string
+	Base
+	secret string
+	Tags   []string ` + "`lit:\"tags,array\"`" + `
+}
+
+type Order struct {
+	Id    int
+	Total int
+}
+
+type Unregistered struct {
+	Id int
+}
+
+func init() {
+	l.RegisterModel[User](l.PostgreSQL)
+	l.RegisterModelWithOptions[Order](l.PostgreSQL)
+}
+`
+
+const expectedOutput = `// Code generated by lit-gen. DO NOT EDIT.
+
+package models
+
+import (
+	"database/sql"
+
+	"github.com/tracewayapp/lit/v2"
+)
+
+func init() {
+	lit.RegisterFieldPointers(func(t *Order, field int) any {
+		switch field {
+		case 0:
+			return &t.Id
+		case 1:
+			return &t.Total
+		}
+		return nil
+	})
+	lit.RegisterScanner(scanOrder, []int{0}, []int{1})
+	lit.RegisterFieldPointers(func(t *User, field int) any {
+		switch field {
+		case 0:
+			return &t.Id
+		case 1:
+			return &t.FirstName
+		case 2:
+			return &t.LastName
+		case 5:
+			return &t.Tags
+		}
+		return nil
+	})
+	lit.RegisterScanner(scanUser, []int{0}, []int{1}, []int{2}, []int{3, 0}, []int{4}, []int{5})
+}
+
+func scanOrder(rows *sql.Rows, t *Order) error {
+	return rows.Scan(&t.Id, &t.Total)
+}
+
+func scanUser(rows *sql.Rows, t *User) error {
+	return rows.Scan(&t.Id, &t.FirstName, &t.LastName, &t.Base.CreatedAt, &t.secret, &t.Tags)
+}
+`
+
+func TestGenerateDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "models.go"), []byte(modelsSource), 0o644))
+
+	source, err := generateDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, expectedOutput, string(source))
+
+	again, err := generateDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, source, again)
+}
+
+func TestGenerateDir_ForeignEmbedded(t *testing.T) {
+	dir := t.TempDir()
+	source := `package models
+
+import (
+	"github.com/tracewayapp/lit/v2"
+	"example.com/shared"
+)
+
+type Account struct {
+	shared.Audit
+	Id int
+}
+
+func init() {
+	lit.RegisterModel[Account](lit.PostgreSQL)
+}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "models.go"), []byte(source), 0o644))
+
+	generated, err := generateDir(dir)
+	require.NoError(t, err)
+	assert.NotContains(t, string(generated), "RegisterScanner")
+	assert.NotContains(t, string(generated), "database/sql")
+	assert.Contains(t, string(generated), "return &t.Id")
+}
+
+func TestGenerateDir_NoModels(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "util.go"), []byte("package util\n\nfunc Add(a, b int) int { return a + b }\n"), 0o644))
+
+	source, err := generateDir(dir)
+	require.NoError(t, err)
+	assert.Nil(t, source)
+}
+
+func TestExpandPattern(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"a/b", "testdata/x", ".git"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0o755))
+	}
+
+	dirs, err := expandPattern(root + "/...")
+	require.NoError(t, err)
+	assert.Equal(t, []string{root, filepath.Join(root, "a"), filepath.Join(root, "a/b")}, dirs)
+
+	dirs, err = expandPattern("models")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"models"}, dirs)
+}
@@ -0,0 +1,92 @@
+package lit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type cockroachDriver struct {
+	pgDriver
+}
+
+var CockroachDB Driver = &cockroachDriver{}
+
+func (d *cockroachDriver) Name() string { return "CockroachDB" }
+
+func (d *cockroachDriver) String() string { return d.Name() }
+
+// GenerateInsertQuery matches the PostgreSQL generator. Models with a UUID id
+// get the gen_random_uuid() fallback from generateInsertQuery instead.
+func (d *cockroachDriver) GenerateInsertQuery(tableName string, columnKeys []string, hasIntId bool) (string, []string) {
+	return d.generateInsertQuery(tableName, columnKeys, hasIntId, false)
+}
+
+// generateInsertQuery lets a UUID id fall back to gen_random_uuid() when the
+// caller leaves it empty, i.e. "" or the nil UUID a zero uuid.UUID binds as.
+// Other ids are bound as they are.
+func (d *cockroachDriver) generateInsertQuery(tableName string, columnKeys []string, hasIntId, hasUuidId bool) (string, []string) {
+	var insertQuery strings.Builder
+
+	insertQuery.WriteString("INSERT INTO ")
+	insertQuery.WriteString(escapeTableName(tableName, pgEscapeReserved))
+	insertQuery.WriteString(" (")
+
+	totalKeys := len(columnKeys)
+	for i, k := range columnKeys {
+		insertQuery.WriteString(pgEscapeReserved(k))
+		if i != totalKeys-1 {
+			insertQuery.WriteString(",")
+		}
+	}
+
+	insertQuery.WriteString(") VALUES (")
+
+	counter := 1
+	insertColumns := []string{}
+	for i, k := range columnKeys {
+		if hasIntId && k == "id" {
+			insertQuery.WriteString("DEFAULT")
+		} else if hasUuidId && k == "id" {
+			insertColumns = append(insertColumns, k)
+			insertQuery.WriteString("COALESCE(NULLIF(NULLIF($" + strconv.Itoa(counter) + ", ''), '" + uuid.Nil.String() + "')::UUID, gen_random_uuid())")
+			counter++
+		} else {
+			insertColumns = append(insertColumns, k)
+			insertQuery.WriteString("$" + strconv.Itoa(counter))
+			counter++
+		}
+		if i != totalKeys-1 {
+			insertQuery.WriteString(",")
+		}
+	}
+	insertQuery.WriteString(") RETURNING id")
+
+	return insertQuery.String(), insertColumns
+}
+
+// generateInsertQuery is driver.GenerateInsertQuery for a model, with the
+// CockroachDB UUID fallback when the model's id is a UUID.
+func generateInsertQuery(driver Driver, tableName string, columnKeys []string, hasIntId, hasUuidId bool) (string, []string) {
+	if d, ok := driver.(*cockroachDriver); ok {
+		return d.generateInsertQuery(tableName, columnKeys, hasIntId, hasUuidId)
+	}
+	return driver.GenerateInsertQuery(tableName, columnKeys, hasIntId)
+}
+
+// InsertAndGetId always reads the id through RETURNING id, since LastInsertId
+// is not supported for CockroachDB.
+func (d *cockroachDriver) InsertAndGetId(ex Executor, query string, args ...any) (int, error) {
+	row := debugged(ex).QueryRow(query, args...)
+	var id int
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// ensure cockroachDriver implements Driver at compile time
+var _ Driver = (*cockroachDriver)(nil)
+var _ fmt.Stringer = (*cockroachDriver)(nil)
@@ -0,0 +1,135 @@
+package lit
+
+import (
+	"database/sql/driver"
+	"reflect"
+)
+
+// CopyExecutor is an Executor that can bulk load rows with PostgreSQL's
+// COPY FROM STDIN protocol. lit does not depend on a PostgreSQL client, so
+// wrap your connection to implement it, e.g. with pgx:
+//
+//	func (c copyConn) CopyFrom(table string, columns []string, rows [][]any) (int64, error) {
+//		return c.conn.CopyFrom(ctx, pgx.Identifier(strings.Split(table, ".")), columns, pgx.CopyFromRows(rows))
+//	}
+type CopyExecutor interface {
+	Executor
+	CopyFrom(tableName string, columns []string, rows [][]any) (int64, error)
+}
+
+// CopyInsert bulk inserts rows and returns the number of rows written. When
+// ex is a CopyExecutor the rows are sent with COPY, leaving an integer id to
+// its sequence; otherwise each row is inserted with a regular INSERT. Only the
+// model's BeforeInsert hook runs, since no ids are read back.
+func CopyInsert[T any](ex Executor, rows []*T) (_ int64, err error) {
+	defer wrapModelError[T]("CopyInsert", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	if err := ValidateColumns[T](fieldMap.InsertColumns, fieldMap); err != nil {
+		return 0, err
+	}
+
+	if err := checkOptionalColumnsForWrite(ex, fieldMap); err != nil {
+		return 0, err
+	}
+
+	copier, ok := ex.(CopyExecutor)
+	if !ok {
+		return insertEach(ex, fieldMap, rows)
+	}
+
+	values, err := copyRows(fieldMap, rows)
+	if err != nil {
+		return 0, err
+	}
+	return copier.CopyFrom(fieldMap.TableName, fieldMap.InsertColumns, values)
+}
+
+// CopyRows prepares rows for a COPY done by the caller: it runs the insert
+// hooks, autocreate timestamps and normalizers like CopyInsert, and returns
+// the table, the columns and one value row per model in column order.
+// Optional columns are not probed, since there is no Executor to probe with.
+func CopyRows[T any](rows []*T) (tableName string, columns []string, values [][]any, err error) {
+	defer wrapModelError[T]("CopyRows", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if err := ValidateColumns[T](fieldMap.InsertColumns, fieldMap); err != nil {
+		return "", nil, nil, err
+	}
+	values, err = copyRows(fieldMap, rows)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return fieldMap.TableName, fieldMap.InsertColumns, values, nil
+}
+
+func copyRows[T any](fieldMap *FieldMap, rows []*T) ([][]any, error) {
+	values := make([][]any, 0, len(rows))
+	for _, t := range rows {
+		if err := fieldMap.Hooks.beforeInsert(t); err != nil {
+			return nil, err
+		}
+		setAutoCreateFields(fieldMap, t)
+		applyNormalizers(fieldMap, t)
+
+		row, err := copyValues(argsForColumns(fieldMap.InsertColumns, fieldMap, t))
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, row)
+	}
+	return values, nil
+}
+
+func insertEach[T any](ex Executor, fieldMap *FieldMap, rows []*T) (int64, error) {
+	var total int64
+	for _, t := range rows {
+		if err := fieldMap.Hooks.beforeInsert(t); err != nil {
+			return total, err
+		}
+		setAutoCreateFields(fieldMap, t)
+		applyNormalizers(fieldMap, t)
+
+		insertQuery, insertColumns := insertQueryFor(fieldMap, t)
+		result, err := debugged(ex).Exec(insertQuery, argsForColumns(insertColumns, fieldMap, t)...)
+		if err != nil {
+			return total, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+		total += affected
+	}
+	return total, nil
+}
+
+// copyValues turns the arguments of argsForColumns into plain values, since
+// COPY takes values rather than pointers. A nil nullable field becomes nil.
+func copyValues(pointers []any) ([]any, error) {
+	row := make([]any, len(pointers))
+	for i, p := range pointers {
+		if valuer, ok := p.(driver.Valuer); ok {
+			v, err := valuer.Value()
+			if err != nil {
+				return nil, err
+			}
+			row[i] = v
+			continue
+		}
+		if v := reflect.ValueOf(p); v.IsNil() {
+			row[i] = nil
+		} else {
+			row[i] = v.Elem().Interface()
+		}
+	}
+	return row, nil
+}
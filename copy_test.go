@@ -0,0 +1,96 @@
+package lit
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingCopyExecutor struct {
+	Executor
+	table   string
+	columns []string
+	rows    [][]any
+}
+
+func (r *recordingCopyExecutor) CopyFrom(tableName string, columns []string, rows [][]any) (int64, error) {
+	r.table = tableName
+	r.columns = columns
+	r.rows = rows
+	return int64(len(rows)), nil
+}
+
+func TestCopyInsert_UsesCopy(t *testing.T) {
+	RegisterModel[TestUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ex := &recordingCopyExecutor{Executor: db}
+	n, err := CopyInsert(ex, []*TestUser{
+		{FirstName: "John", LastName: "Doe", Email: "john@example.com"},
+		{FirstName: "Jane", LastName: "Roe", Email: "jane@example.com"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.Equal(t, "test_users", ex.table)
+	assert.Equal(t, []string{"first_name", "last_name", "email"}, ex.columns)
+	assert.Equal(t, [][]any{
+		{"John", "Doe", "john@example.com"},
+		{"Jane", "Roe", "jane@example.com"},
+	}, ex.rows)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCopyInsert_FallsBackToInsert(t *testing.T) {
+	RegisterModel[TestUser](MySQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO test_users").
+		WithArgs("John", "Doe", "john@example.com").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO test_users").
+		WithArgs("Jane", "Roe", "jane@example.com").
+		WillReturnResult(sqlmock.NewResult(2, 1))
+
+	n, err := CopyInsert(db, []*TestUser{
+		{FirstName: "John", LastName: "Doe", Email: "john@example.com"},
+		{FirstName: "Jane", LastName: "Roe", Email: "jane@example.com"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCopyInsert_Empty(t *testing.T) {
+	RegisterModel[TestUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	n, err := CopyInsert[TestUser](db, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCopyRows(t *testing.T) {
+	RegisterModel[TestUser](PostgreSQL)
+
+	table, columns, values, err := CopyRows([]*TestUser{
+		{FirstName: "John", LastName: "Doe", Email: "john@example.com"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "test_users", table)
+	assert.Equal(t, []string{"first_name", "last_name", "email"}, columns)
+	assert.Equal(t, [][]any{{"John", "Doe", "john@example.com"}}, values)
+}
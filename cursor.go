@@ -0,0 +1,162 @@
+package lit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"iter"
+	"reflect"
+)
+
+// Cursor streams the rows of a query one model at a time, see SelectCursor.
+type Cursor[T any] struct {
+	rows     *sql.Rows
+	fieldMap *FieldMap
+	columns  []string
+	current  *T
+	err      error
+}
+
+// SelectCursor runs query and returns a cursor over its rows instead of
+// loading them all into memory. Always Close the cursor:
+//
+//	cursor, err := lit.SelectCursor[User](db, "SELECT * FROM users")
+//	if err != nil {
+//		return err
+//	}
+//	defer cursor.Close()
+//	for cursor.Next() {
+//		user := cursor.Value()
+//		...
+//	}
+//	return cursor.Err()
+func SelectCursor[T any](ex Executor, query string, args ...any) (_ *Cursor[T], err error) {
+	defer wrapModelError[T]("SelectCursor", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := debugged(ex).Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		rows.Close()
+		return nil, err
+	}
+
+	if err := ValidateColumns[T](columns, fieldMap); err != nil {
+		rows.Close()
+		return nil, err
+	}
+
+	return &Cursor[T]{rows: rows, fieldMap: fieldMap, columns: columns}, nil
+}
+
+// Next scans the next row, returning false when there are no more rows or
+// scanning failed; check Err afterwards.
+func (c *Cursor[T]) Next() bool {
+	c.current = nil
+	if c.err != nil || !c.rows.Next() {
+		return false
+	}
+	var t T
+	if err := scanRow(c.rows, c.columns, c.fieldMap, &t); err != nil {
+		c.err = err
+		return false
+	}
+	c.current = &t
+	return true
+}
+
+// Value returns the row scanned by the last call to Next. Each row is a new
+// value, so it may be kept after advancing the cursor.
+func (c *Cursor[T]) Value() *T {
+	return c.current
+}
+
+// Err returns the error that stopped Next, if any.
+func (c *Cursor[T]) Err() (err error) {
+	defer wrapModelError[T]("SelectCursor", &err)
+	if c.err != nil {
+		return c.err
+	}
+	return c.rows.Err()
+}
+
+// Close closes the underlying rows. It is safe to call more than once.
+func (c *Cursor[T]) Close() error {
+	return c.rows.Close()
+}
+
+// SelectEach runs query and calls fn with each row, scanned into a fresh T,
+// without loading the whole result. Returning an error from fn stops the
+// iteration and returns that error, except for Stop, which ends it cleanly.
+func SelectEach[T any](ex Executor, query string, fn func(*T) error, args ...any) (err error) {
+	defer wrapModelError[T]("SelectEach", &err)
+	cursor, err := SelectCursor[T](ex, query, args...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	for cursor.Next() {
+		if err := fn(cursor.Value()); err != nil {
+			if errors.Is(err, Stop) {
+				return nil
+			}
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// SelectIter runs query and yields its rows one at a time. A failing query,
+// scan or rows.Err() is yielded as the final (nil, err) pair. The rows are
+// closed when iteration ends, also when the loop breaks early:
+//
+//	for user, err := range lit.SelectIter[User](db, "SELECT * FROM users") {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func SelectIter[T any](ex Executor, query string, args ...any) iter.Seq2[*T, error] {
+	return SelectIterContext[T](context.Background(), ex, query, args...)
+}
+
+// SelectIterContext is SelectIter stopping with ctx.Err() once ctx is done.
+func SelectIterContext[T any](ctx context.Context, ex Executor, query string, args ...any) iter.Seq2[*T, error] {
+	fail := func(yield func(*T, error) bool, err error) {
+		wrapModelError[T]("SelectIter", &err)
+		yield(nil, err)
+	}
+	return func(yield func(*T, error) bool) {
+		if err := ctx.Err(); err != nil {
+			fail(yield, err)
+			return
+		}
+		cursor, err := SelectCursor[T](ex, query, args...)
+		if err != nil {
+			fail(yield, err)
+			return
+		}
+		defer cursor.Close()
+
+		for cursor.Next() {
+			if err := ctx.Err(); err != nil {
+				fail(yield, err)
+				return
+			}
+			if !yield(cursor.Value(), nil) {
+				return
+			}
+		}
+		if err := cursor.Err(); err != nil {
+			fail(yield, err)
+		}
+	}
+}
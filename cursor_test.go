@@ -0,0 +1,197 @@
+package lit
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectCursor(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id, first_name FROM test_users").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "first_name"}).
+			AddRow(1, "John").AddRow(2, "Jane")).
+		RowsWillBeClosed()
+
+	cursor, err := SelectCursor[TestUser](db, "SELECT id, first_name FROM test_users")
+	require.NoError(t, err)
+
+	var users []*TestUser
+	for cursor.Next() {
+		users = append(users, cursor.Value())
+	}
+	require.NoError(t, cursor.Err())
+	assert.Nil(t, cursor.Value())
+	require.NoError(t, cursor.Close())
+
+	require.Len(t, users, 2)
+	assert.Equal(t, "John", users[0].FirstName)
+	assert.Equal(t, "Jane", users[1].FirstName)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectCursor_ScanError(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id FROM test_users").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("not a number").AddRow(2))
+
+	cursor, err := SelectCursor[TestUser](db, "SELECT id FROM test_users")
+	require.NoError(t, err)
+	defer cursor.Close()
+
+	assert.False(t, cursor.Next())
+	assert.Error(t, cursor.Err())
+	assert.False(t, cursor.Next())
+}
+
+func TestSelectCursor_UnknownColumn(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT nickname FROM test_users").
+		WillReturnRows(sqlmock.NewRows([]string{"nickname"})).
+		RowsWillBeClosed()
+
+	_, err = SelectCursor[TestUser](db, "SELECT nickname FROM test_users")
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectIter(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id, first_name FROM test_users").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "first_name"}).
+			AddRow(1, "John").AddRow(2, "Jane").AddRow(3, "Jack")).
+		RowsWillBeClosed()
+	mock.ExpectQuery("SELECT id, first_name FROM test_users").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "first_name"}).
+			AddRow(1, "John").AddRow(2, "Jane").
+			RowError(1, errors.New("connection reset"))).
+		RowsWillBeClosed()
+
+	var names []string
+	for user, err := range SelectIter[TestUser](db, "SELECT id, first_name FROM test_users") {
+		require.NoError(t, err)
+		names = append(names, user.FirstName)
+		if len(names) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"John", "Jane"}, names)
+
+	var lastErr error
+	count := 0
+	for user, err := range SelectIter[TestUser](db, "SELECT id, first_name FROM test_users") {
+		if err != nil {
+			assert.Nil(t, user)
+			lastErr = err
+			continue
+		}
+		count++
+	}
+	assert.Equal(t, 1, count)
+	assert.EqualError(t, lastErr, "lit: TestUser.SelectIter: connection reset")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectIterContext_Cancelled(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id FROM test_users").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2).AddRow(3)).
+		RowsWillBeClosed()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var ids []int
+	var lastErr error
+	for user, err := range SelectIterContext[TestUser](ctx, db, "SELECT id FROM test_users") {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		ids = append(ids, user.Id)
+		cancel()
+	}
+	assert.Equal(t, []int{1}, ids)
+	assert.ErrorIs(t, lastErr, context.Canceled)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectEach(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	for range 3 {
+		mock.ExpectQuery("SELECT id, first_name FROM test_users").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "first_name"}).
+				AddRow(1, "John").AddRow(2, "Jane").AddRow(3, "Jack")).
+			RowsWillBeClosed()
+	}
+
+	var names []string
+	err = SelectEach(db, "SELECT id, first_name FROM test_users", func(user *TestUser) error {
+		names = append(names, user.FirstName)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"John", "Jane", "Jack"}, names)
+
+	names = nil
+	err = SelectEach(db, "SELECT id, first_name FROM test_users", func(user *TestUser) error {
+		names = append(names, user.FirstName)
+		if user.Id == 2 {
+			return Stop
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"John", "Jane"}, names)
+
+	failed := errors.New("queue full")
+	err = SelectEach(db, "SELECT id, first_name FROM test_users", func(user *TestUser) error {
+		return failed
+	})
+	assert.ErrorIs(t, err, failed)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
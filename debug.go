@@ -0,0 +1,60 @@
+package lit
+
+import (
+	"database/sql"
+	"io"
+	"log/slog"
+	"sync/atomic"
+)
+
+var debugLogger atomic.Pointer[slog.Logger]
+
+// SetDebug writes every statement lit runs, with its args, to w as slog text
+// lines before it is executed. A nil w turns debug output off.
+func SetDebug(w io.Writer) {
+	if w == nil {
+		SetDebugLogger(nil)
+		return
+	}
+	SetDebugLogger(slog.New(slog.NewTextHandler(w, nil)))
+}
+
+// SetDebugLogger is SetDebug writing to l, e.g. an application's existing
+// logger. Statements are logged at info level. A nil l turns debug output off.
+func SetDebugLogger(l *slog.Logger) {
+	debugLogger.Store(l)
+}
+
+type debugExecutor struct {
+	ex     Executor
+	logger *slog.Logger
+}
+
+// debugged wraps ex to log its statements while debug output is on, and
+// returns ex itself otherwise.
+func debugged(ex Executor) Executor {
+	logger := debugLogger.Load()
+	if logger == nil {
+		return ex
+	}
+	return debugExecutor{ex: ex, logger: logger}
+}
+
+func (d debugExecutor) Exec(query string, args ...any) (sql.Result, error) {
+	d.log(query, args)
+	return d.ex.Exec(query, args...)
+}
+
+func (d debugExecutor) Query(query string, args ...any) (*sql.Rows, error) {
+	d.log(query, args)
+	return d.ex.Query(query, args...)
+}
+
+func (d debugExecutor) QueryRow(query string, args ...any) *sql.Row {
+	d.log(query, args)
+	return d.ex.QueryRow(query, args...)
+}
+
+func (d debugExecutor) log(query string, args []any) {
+	d.logger.Info("lit query", "query", query, "args", args)
+}
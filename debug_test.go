@@ -0,0 +1,65 @@
+package lit
+
+import (
+	"bytes"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetDebug(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	var buf bytes.Buffer
+	SetDebug(&buf)
+	defer SetDebug(nil)
+
+	mock.ExpectQuery("SELECT").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	_, err = Select[TestUser](db, "SELECT id FROM test_users WHERE id = $1", 1)
+	require.NoError(t, err)
+
+	mock.ExpectExec("DELETE").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, Delete(db, "DELETE FROM test_users WHERE id = $1", 1))
+
+	assert.Contains(t, buf.String(), `msg="lit query" query="SELECT id FROM test_users WHERE id = $1" args=[1]`)
+	assert.Contains(t, buf.String(), `query="DELETE FROM test_users WHERE id = $1" args=[1]`)
+
+	SetDebug(nil)
+	buf.Reset()
+	mock.ExpectExec("DELETE").
+		WithArgs(2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, Delete(db, "DELETE FROM test_users WHERE id = $1", 2))
+	assert.Empty(t, buf.String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetDebugLogger(t *testing.T) {
+	var buf bytes.Buffer
+	SetDebugLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	defer SetDebugLogger(nil)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("DELETE").
+		WithArgs("x").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, Delete(db, "DELETE FROM tokens WHERE token = $1", "x"))
+
+	assert.Contains(t, buf.String(), `"msg":"lit query","query":"DELETE FROM tokens WHERE token = $1","args":["x"]`)
+}
@@ -0,0 +1,45 @@
+package lit
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// WithDB stores the database the model lives in, for setups where models are
+// spread over several databases. See RegisterModelWithDB.
+func WithDB(db *sql.DB) ModelOption {
+	return func(fieldMap *FieldMap) {
+		fieldMap.DefaultDB = db
+	}
+}
+
+// RegisterModelWithDB registers T with driver and remembers db as the
+// database T lives in, so InsertDefault and DefaultExecutor can be used
+// without passing it around.
+func RegisterModelWithDB[T any](driver Driver, db *sql.DB) error {
+	return RegisterModelWithOptions[T](driver, WithDB(db))
+}
+
+// DefaultExecutor returns the model's DefaultDB, or nil when it has none.
+func (fieldMap *FieldMap) DefaultExecutor() Executor {
+	if fieldMap.DefaultDB == nil {
+		return nil
+	}
+	return fieldMap.DefaultDB
+}
+
+// InsertDefault is Insert on the database T was registered with through
+// RegisterModelWithDB.
+func InsertDefault[T any](t *T) (_ int, err error) {
+	defer wrapModelError[T]("InsertDefault", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return 0, err
+	}
+	ex := fieldMap.DefaultExecutor()
+	if ex == nil {
+		return 0, fmt.Errorf("model %s has no default database, register it with lit.RegisterModelWithDB", reflect.TypeFor[T]().Name())
+	}
+	return Insert(ex, t)
+}
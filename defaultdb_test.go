@@ -0,0 +1,52 @@
+package lit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertDefault(t *testing.T) {
+	usersDb, usersMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer usersDb.Close()
+	productsDb, productsMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer productsDb.Close()
+
+	t.Cleanup(RegisterModelScoped[TestUser](PostgreSQL))
+	RegisterModelWithDB[TestUser](PostgreSQL, usersDb)
+	t.Cleanup(RegisterModelScoped[TestProduct](PostgreSQL))
+	RegisterModelWithDB[TestProduct](PostgreSQL, productsDb)
+
+	usersMock.ExpectQuery("INSERT INTO test_users").
+		WithArgs("John", "Doe", "john@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	productsMock.ExpectQuery("INSERT INTO test_products").
+		WithArgs("p1", "Widget", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+
+	id, err := InsertDefault(&TestUser{FirstName: "John", LastName: "Doe", Email: "john@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, 7, id)
+
+	_, err = InsertDefault(&TestProduct{Id: "p1", Name: "Widget", Price: 10})
+	require.NoError(t, err)
+
+	assert.NoError(t, usersMock.ExpectationsWereMet())
+	assert.NoError(t, productsMock.ExpectationsWereMet())
+}
+
+func TestInsertDefault_NoDB(t *testing.T) {
+	t.Cleanup(RegisterModelScoped[TestUser](PostgreSQL))
+
+	fieldMap, err := GetFieldMap(reflect.TypeFor[TestUser]())
+	require.NoError(t, err)
+	assert.Nil(t, fieldMap.DefaultExecutor())
+
+	_, err = InsertDefault(&TestUser{})
+	assert.EqualError(t, err, "lit: TestUser.InsertDefault: model TestUser has no default database, register it with lit.RegisterModelWithDB")
+}
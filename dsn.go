@@ -0,0 +1,64 @@
+package lit
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrUnknownDriver is returned when a database/sql driver can't be mapped to a
+// lit Driver.
+var ErrUnknownDriver = errors.New("lit: unknown database driver")
+
+// driversByName maps the names database/sql drivers register under to lit
+// drivers. CockroachDB uses the PostgreSQL drivers, so those map to PostgreSQL.
+var driversByName = map[string]Driver{
+	"pgx":        PostgreSQL,
+	"pgx/v4":     PostgreSQL,
+	"pgx/v5":     PostgreSQL,
+	"postgres":   PostgreSQL,
+	"postgresql": PostgreSQL,
+	"mysql":      MySQL,
+	"sqlite3":    SQLite,
+	"sqlite":     SQLite,
+}
+
+// driversByPackage maps the package of a database/sql driver implementation
+// to its registered name.
+var driversByPackage = map[string]string{
+	"github.com/jackc/pgx/v4/stdlib": "pgx/v4",
+	"github.com/jackc/pgx/v5/stdlib": "pgx/v5",
+	"github.com/lib/pq":              "postgres",
+	"github.com/go-sql-driver/mysql": "mysql",
+	"github.com/mattn/go-sqlite3":    "sqlite3",
+	"modernc.org/sqlite":             "sqlite",
+}
+
+// DriverFromDSN returns the lit Driver for a database/sql driver name, the
+// first argument to sql.Open, e.g. "pgx", "mysql" or "sqlite3".
+func DriverFromDSN(driverName string) (Driver, error) {
+	if driver, ok := driversByName[strings.ToLower(driverName)]; ok {
+		return driver, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownDriver, driverName)
+}
+
+// RegisterModelFromDB registers T with the lit Driver matching the driver db
+// was opened with. Only the common drivers listed in DriverFromDSN are known.
+func RegisterModelFromDB[T any](db *sql.DB) error {
+	t := reflect.TypeOf(db.Driver())
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	name, ok := driversByPackage[t.PkgPath()]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownDriver, t.String())
+	}
+	driver, err := DriverFromDSN(name)
+	if err != nil {
+		return err
+	}
+	return RegisterModel[T](driver)
+}
@@ -0,0 +1,49 @@
+package lit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriverFromDSN(t *testing.T) {
+	for name, expected := range map[string]Driver{
+		"pgx":      PostgreSQL,
+		"postgres": PostgreSQL,
+		"mysql":    MySQL,
+		"sqlite3":  SQLite,
+		"sqlite":   SQLite,
+	} {
+		driver, err := DriverFromDSN(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, expected, driver, name)
+	}
+
+	_, err := DriverFromDSN("sqlserver")
+	assert.ErrorIs(t, err, ErrUnknownDriver)
+	assert.EqualError(t, err, "lit: unknown database driver: sqlserver")
+}
+
+func TestRegisterModelFromDB(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	err = RegisterModelFromDB[TestUser](db)
+	assert.ErrorIs(t, err, ErrUnknownDriver)
+	_, err = GetFieldMap(reflect.TypeFor[TestUser]())
+	assert.ErrorAs(t, err, &NotRegisteredError{})
+
+	driversByPackage["github.com/DATA-DOG/go-sqlmock"] = "mysql"
+	defer delete(driversByPackage, "github.com/DATA-DOG/go-sqlmock")
+
+	require.NoError(t, RegisterModelFromDB[TestUser](db))
+	fieldMap, err := GetFieldMap(reflect.TypeFor[TestUser]())
+	require.NoError(t, err)
+	assert.Equal(t, MySQL, fieldMap.Driver)
+}
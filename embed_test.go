@@ -0,0 +1,77 @@
+package lit
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type TestBaseModel struct {
+	Id        int
+	CreatedAt time.Time `lit:"created_at,autocreate"`
+}
+
+type TestMember struct {
+	TestBaseModel
+	Email string
+}
+
+type TestShadowedMember struct {
+	TestBaseModel
+	CreatedAt string `lit:"created_label"`
+}
+
+func TestRegisterModel_EmbeddedStruct(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestMember]())
+	RegisterModel[TestMember](PostgreSQL)
+
+	fieldMap, err := GetFieldMap(reflect.TypeFor[TestMember]())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "created_at", "email"}, fieldMap.ColumnKeys)
+	assert.Equal(t, map[string]int{"id": 0, "created_at": 1, "email": 2}, fieldMap.ColumnsMap)
+	assert.Equal(t, [][]int{{0, 0}, {0, 1}, {1}}, fieldMap.FieldIndexes)
+	assert.True(t, fieldMap.HasIntId)
+	assert.Equal(t, []int{1}, fieldMap.AutoCreateFields)
+}
+
+func TestRegisterModel_EmbeddedFieldShadowed(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestShadowedMember]())
+	RegisterModel[TestShadowedMember](PostgreSQL)
+
+	fieldMap, err := GetFieldMap(reflect.TypeFor[TestShadowedMember]())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "created_label"}, fieldMap.ColumnKeys)
+	assert.Equal(t, [][]int{{0, 0}, {1}}, fieldMap.FieldIndexes)
+}
+
+func TestEmbeddedStruct_InsertAndSelect(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestMember]())
+	RegisterModel[TestMember](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	createdAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("INSERT INTO test_members \\(id,created_at,email\\) VALUES \\(DEFAULT,\\$1,\\$2\\) RETURNING id").
+		WithArgs(createdAt, "john@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+	mock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "email"}).AddRow(5, createdAt, "john@example.com"))
+
+	id, err := Insert(db, &TestMember{TestBaseModel: TestBaseModel{CreatedAt: createdAt}, Email: "john@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, 5, id)
+
+	member, err := SelectSingle[TestMember](db, "SELECT * FROM test_members")
+	require.NoError(t, err)
+	assert.Equal(t, 5, member.Id)
+	assert.Equal(t, createdAt, member.CreatedAt)
+	assert.Equal(t, "john@example.com", member.Email)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,94 @@
+package lit
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrNotFound is returned when no row matches, e.g. by SelectSingleOrNotFound,
+// SelectSingleStrict or DeleteById.
+var ErrNotFound = errors.New("lit: no rows found")
+
+// ErrUnsupportedOperation is returned when the model's driver cannot perform
+// the requested operation, e.g. row locking on SQLite.
+var ErrUnsupportedOperation = errors.New("lit: operation not supported by driver")
+
+// ErrMultipleRows is returned by SelectExactlyOne when more than one row
+// matches.
+var ErrMultipleRows = errors.New("lit: multiple rows found")
+
+// ErrNoRowsAffected is returned by the *Strict variants when a write changed
+// no rows.
+var ErrNoRowsAffected = errors.New("lit: no rows affected")
+
+// ErrTooManyRows is returned by the *Strict variants when a write changed more
+// rows than expected.
+var ErrTooManyRows = errors.New("lit: too many rows affected")
+
+// ErrEmptyIn is returned by InClauseStrict for an empty value list.
+var ErrEmptyIn = errors.New("lit: empty IN list")
+
+// ErrUnusedParams is returned by ParseNamedQueryStrict, the *Named functions
+// given WithStrictParams, or ParseNamedQuery in strict mode, when params has
+// keys the query doesn't reference.
+var ErrUnusedParams = errors.New("lit: unused named parameters")
+
+// ErrAlreadyRegistered is returned when registering a model that is already
+// registered with another driver.
+var ErrAlreadyRegistered = errors.New("lit: model already registered")
+
+// Stop can be returned from a SelectEach callback to end iteration early
+// without an error.
+var Stop = errors.New("lit: stop iteration")
+
+// NotRegisteredError is returned when a model is used before RegisterModel was
+// called for it.
+type NotRegisteredError struct {
+	TypeName string
+}
+
+func (e NotRegisteredError) Error() string {
+	return fmt.Sprintf("non registered model %s used. Please call `lit.RegisterModel[%s](driver)` after you define %s", e.TypeName, e.TypeName, e.TypeName)
+}
+
+// ModelError is returned by the operations on a model, e.g. Select or Insert,
+// and names the model and operation that failed, as in
+// "lit: User.Insert: pq: duplicate key value violates unique constraint".
+// Use errors.Is and errors.As to check the error it wraps.
+type ModelError struct {
+	Model string
+	Op    string
+	Err   error
+}
+
+func (e *ModelError) Error() string {
+	// Sentinels such as ErrNotFound carry their own "lit: " prefix.
+	return "lit: " + e.Model + "." + e.Op + ": " + strings.TrimPrefix(e.Err.Error(), "lit: ")
+}
+
+func (e *ModelError) Unwrap() error {
+	return e.Err
+}
+
+// wrapModelError wraps *err in a ModelError for T and op. A ModelError of T
+// returned as is by an operation op is built on, e.g. SelectSingle under
+// SelectById, is renamed to op. A ModelError further down the chain, or of
+// another model, already names where it failed and is left alone, so the
+// message never carries two prefixes.
+func wrapModelError[T any](op string, err *error) {
+	if *err == nil {
+		return
+	}
+	model := reflect.TypeFor[T]().Name()
+	if modelErr, ok := (*err).(*ModelError); ok && modelErr.Model == model {
+		*err = &ModelError{Model: model, Op: op, Err: modelErr.Err}
+		return
+	}
+	var nested *ModelError
+	if errors.As(*err, &nested) {
+		return
+	}
+	*err = &ModelError{Model: model, Op: op, Err: *err}
+}
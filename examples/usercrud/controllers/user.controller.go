@@ -78,11 +78,24 @@ func (userController *userController) UpdateUser(w http.ResponseWriter, r *http.
 	}
 	user.Id = id
 
-	err = repositories.UserRepository.Update(connections.DB, user)
+	updated, err := repositories.UserRepository.Update(connections.DB, user)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if updated == 0 {
+		// Zero rows can also be an unchanged row on MySQL, so check that the
+		// user exists before answering 404.
+		existing, err := repositories.UserRepository.FindById(connections.DB, id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if existing == nil {
+			http.Error(w, "user not found", http.StatusNotFound)
+			return
+		}
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(user)
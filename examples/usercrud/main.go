@@ -35,6 +35,13 @@ func main() {
 	connections.InitDB(driver, dsn)
 	defer connections.CleanupDB()
 
+	if errs := lit.ValidateRegistry(connections.DB); len(errs) > 0 {
+		for _, err := range errs {
+			log.Println(err)
+		}
+		log.Fatal("invalid model registrations")
+	}
+
 	http.HandleFunc("GET /users", controllers.UserController.ListUsers)
 	http.HandleFunc("GET /users/{id}", controllers.UserController.GetUser)
 	http.HandleFunc("POST /users", controllers.UserController.CreateUser)
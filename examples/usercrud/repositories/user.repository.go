@@ -19,12 +19,19 @@ func (userRepository *userRepository) FindById(db *sql.DB, id int) (*models.User
 	return lit.SelectSingle[models.User](db, "SELECT id, first_name, last_name, email FROM users WHERE id = $1", id)
 }
 
+func (userRepository *userRepository) FindByEmails(db *sql.DB, emails []string) ([]*models.User, error) {
+	in, args := lit.InArgsFor[models.User](0, emails)
+	return lit.Select[models.User](db, "SELECT id, first_name, last_name, email FROM users WHERE email IN ("+in+")", args...)
+}
+
 func (userRepository *userRepository) FindAll(db *sql.DB) ([]*models.User, error) {
 	return lit.Select[models.User](db, "SELECT id, first_name, last_name, email FROM users")
 }
 
-func (userRepository *userRepository) Update(db *sql.DB, user models.User) error {
-	return lit.Update(db, &user, "id = $1", user.Id)
+// Update returns the number of updated rows. On MySQL a row whose values
+// didn't change counts as 0 unless the DSN sets clientFoundRows=true.
+func (userRepository *userRepository) Update(db *sql.DB, user models.User) (int64, error) {
+	return lit.UpdateByIdAffected(db, &user)
 }
 
 func (userRepository *userRepository) Delete(db *sql.DB, id int) error {
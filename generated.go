@@ -0,0 +1,121 @@
+package lit
+
+import (
+	"database/sql"
+	"reflect"
+	"slices"
+)
+
+// fieldPointerFuncs holds the accessors installed by RegisterFieldPointers,
+// so models registered later pick them up too.
+var fieldPointerFuncs = map[reflect.Type]func(t any, field int) any{}
+
+// RegisterFieldPointers installs a reflection-free accessor for T, returning a
+// pointer to the top-level struct field with the given index, or nil when it
+// doesn't handle that field. GetPointersForColumns uses it for plain fields and
+// falls back to reflection for the rest. It is called from the init function of
+// the code generated by cmd/lit-gen:
+//
+//	lit.RegisterFieldPointers(func(t *User, field int) any {
+//		switch field {
+//		case 0:
+//			return &t.Id
+//		}
+//		return nil
+//	})
+func RegisterFieldPointers[T any](fn func(t *T, field int) any) {
+	accessor := func(t any, field int) any {
+		return fn(t.(*T), field)
+	}
+	typ := reflect.TypeFor[T]()
+	fieldPointerFuncs[typ] = accessor
+	if fieldMap, ok := StructToFieldMap[typ]; ok {
+		fieldMap.fieldPointers = accessor
+	}
+}
+
+// generatedScanner is a scan function installed by RegisterScanner together
+// with the index chains of the fields it reads, in order.
+type generatedScanner struct {
+	scan   func(rows *sql.Rows, t any) error
+	fields [][]int
+}
+
+// scannerFuncs holds the scan functions installed by RegisterScanner, so
+// models registered later pick them up too.
+var scannerFuncs = map[reflect.Type]*generatedScanner{}
+
+// RegisterScanner installs a generated scan function for T. fields holds the
+// index chain of each field scan reads into, in scan order. Queries returning
+// exactly those columns in that order, as the queries lit builds for T do,
+// are scanned by calling scan instead of collecting a destination per column.
+// It is not used when the model's fields don't match or any of them is a
+// pointer, json, array or time field, which need lit's conversions. It is
+// called from the init function of the code generated by cmd/lit-gen:
+//
+//	lit.RegisterScanner(scanUser, []int{0}, []int{1}, []int{2, 0})
+func RegisterScanner[T any](scan func(rows *sql.Rows, t *T) error, fields ...[]int) {
+	scanner := &generatedScanner{
+		scan: func(rows *sql.Rows, t any) error {
+			return scan(rows, t.(*T))
+		},
+		fields: fields,
+	}
+	typ := reflect.TypeFor[T]()
+	scannerFuncs[typ] = scanner
+	if fieldMap, ok := StructToFieldMap[typ]; ok {
+		fieldMap.scanner, fieldMap.scanColumns = bindScanner(typ, fieldMap, scanner)
+	}
+}
+
+// bindScanner returns scanner's scan function and the columns it reads, or
+// nil when its fields aren't plain fields of fieldMap.
+func bindScanner(t reflect.Type, fieldMap *FieldMap, scanner *generatedScanner) (func(rows *sql.Rows, t any) error, []string) {
+	if scanner == nil {
+		return nil, nil
+	}
+	columns := make([]string, len(scanner.fields))
+	for i, index := range scanner.fields {
+		pos := slices.IndexFunc(fieldMap.FieldIndexes, func(fieldIndex []int) bool {
+			return slices.Equal(fieldIndex, index)
+		})
+		if pos < 0 || !plainField(t, fieldMap, pos) {
+			return nil, nil
+		}
+		columns[i] = fieldMap.ColumnKeys[pos]
+	}
+	return scanner.scan, columns
+}
+
+// scanRow scans the current row into t, through the generated scan function
+// when columns are the ones it reads.
+func scanRow[T any](rows *sql.Rows, columns []string, fieldMap *FieldMap, t *T) error {
+	if fieldMap.scanner != nil && slices.Equal(columns, fieldMap.scanColumns) {
+		return fieldMap.scanner(rows, t)
+	}
+	return rows.Scan(*GetPointersForColumns[T](columns, fieldMap, t)...)
+}
+
+// directFields reports by column position whether the scan destination is
+// the plain address of a top-level field, i.e. whether a generated accessor
+// can stand in for reflection.
+func directFields(t reflect.Type, fieldMap *FieldMap) []bool {
+	direct := make([]bool, len(fieldMap.FieldIndexes))
+	for pos, index := range fieldMap.FieldIndexes {
+		direct[pos] = len(index) == 1 && plainField(t, fieldMap, pos)
+	}
+	return direct
+}
+
+// plainField reports whether the field at pos is scanned into through its
+// own address, without a pointer, json, array or time conversion.
+func plainField(t reflect.Type, fieldMap *FieldMap, pos int) bool {
+	if t.FieldByIndex(fieldMap.FieldIndexes[pos]).Type.Kind() == reflect.Pointer {
+		return false
+	}
+	if slices.Contains(fieldMap.JSONFields, pos) || slices.Contains(fieldMap.ArrayFields, pos) || slices.Contains(fieldMap.TimeFields, pos) {
+		return false
+	}
+	_, ok := fieldMap.TimeFormats[pos]
+	return !ok
+}
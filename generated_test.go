@@ -0,0 +1,140 @@
+package lit
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type TestGeneratedUser struct {
+	Id        int
+	Name      string
+	Nickname  *string
+	CreatedAt time.Time
+}
+
+func TestRegisterFieldPointers(t *testing.T) {
+	typ := reflect.TypeFor[TestGeneratedUser]()
+	defer delete(fieldPointerFuncs, typ)
+	defer delete(StructToFieldMap, typ)
+
+	var calls []int
+	RegisterFieldPointers(func(u *TestGeneratedUser, field int) any {
+		calls = append(calls, field)
+		switch field {
+		case 0:
+			return &u.Id
+		}
+		return nil
+	})
+	RegisterModel[TestGeneratedUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "nickname", "created_at"}).
+			AddRow(1, "John", "jd", createdAt))
+
+	users, err := Select[TestGeneratedUser](db, "SELECT id, name, nickname, created_at FROM test_generated_users")
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, 1, users[0].Id)
+	assert.Equal(t, "John", users[0].Name)
+	assert.Equal(t, "jd", *users[0].Nickname)
+	assert.Equal(t, createdAt, users[0].CreatedAt)
+
+	// The pointer and time fields go through reflection, the accessor only
+	// sees plain fields and falls back on nil.
+	assert.Equal(t, []int{0, 1}, calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRegisterFieldPointers_AfterRegisterModel(t *testing.T) {
+	typ := reflect.TypeFor[TestGeneratedUser]()
+	defer delete(fieldPointerFuncs, typ)
+	defer delete(StructToFieldMap, typ)
+
+	RegisterModel[TestGeneratedUser](PostgreSQL)
+	called := false
+	RegisterFieldPointers(func(u *TestGeneratedUser, field int) any {
+		called = true
+		return nil
+	})
+
+	user := &TestGeneratedUser{}
+	GetPointersForColumns([]string{"name"}, StructToFieldMap[typ], user)
+	assert.True(t, called)
+}
+
+type TestScannedItem struct {
+	Id    int
+	Title string
+	TestScannedBase
+}
+
+type TestScannedBase struct {
+	Sku string
+}
+
+func scanTestScannedItem(rows *sql.Rows, t *TestScannedItem) error {
+	return rows.Scan(&t.Id, &t.Title, &t.TestScannedBase.Sku)
+}
+
+func TestRegisterScanner(t *testing.T) {
+	typ := reflect.TypeFor[TestScannedItem]()
+	defer delete(scannerFuncs, typ)
+	defer delete(StructToFieldMap, typ)
+
+	calls := 0
+	RegisterScanner(func(rows *sql.Rows, item *TestScannedItem) error {
+		calls++
+		return scanTestScannedItem(rows, item)
+	}, []int{0}, []int{1}, []int{2, 0})
+	RegisterModel[TestScannedItem](PostgreSQL)
+	assert.Equal(t, []string{"id", "title", "sku"}, StructToFieldMap[typ].scanColumns)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "sku"}).
+			AddRow(1, "Lamp", "L-1").
+			AddRow(2, "Desk", "D-2"))
+	items, err := Select[TestScannedItem](db, "SELECT id, title, sku FROM test_scanned_items")
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, TestScannedItem{Id: 2, Title: "Desk", TestScannedBase: TestScannedBase{Sku: "D-2"}}, *items[1])
+	assert.Equal(t, 2, calls)
+
+	// Other columns fall back to GetPointersForColumns.
+	mock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"title"}).AddRow("Lamp"))
+	items, err = Select[TestScannedItem](db, "SELECT title FROM test_scanned_items")
+	require.NoError(t, err)
+	assert.Equal(t, "Lamp", items[0].Title)
+	assert.Equal(t, 2, calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRegisterScanner_ConvertedFields(t *testing.T) {
+	typ := reflect.TypeFor[TestGeneratedUser]()
+	defer delete(scannerFuncs, typ)
+	defer delete(StructToFieldMap, typ)
+
+	RegisterModel[TestGeneratedUser](PostgreSQL)
+	RegisterScanner(func(rows *sql.Rows, u *TestGeneratedUser) error {
+		return rows.Scan(&u.Id, &u.Name, &u.Nickname, &u.CreatedAt)
+	}, []int{0}, []int{1}, []int{2}, []int{3})
+
+	// Nickname and CreatedAt need lit's conversions, so the scanner is unused.
+	assert.Nil(t, StructToFieldMap[typ].scanner)
+}
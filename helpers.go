@@ -6,10 +6,26 @@ import (
 	"strings"
 )
 
-func JoinForIn(ids []int) string {
+// integer is satisfied by every integer type, like constraints.Integer.
+type integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// JoinForIn interpolates integer ids directly into the query. It is kept for
+// integer-only legacy use; prefer InClause, which binds the values as arguments
+// and handles empty lists. No ids give "NULL", like InArgs, so "IN (NULL)"
+// matches no rows instead of failing on "IN ()".
+func JoinForIn[T integer](ids []T) string {
+	if len(ids) == 0 {
+		return "NULL"
+	}
 	var sb strings.Builder
 	for index, id := range ids {
-		sb.WriteString(strconv.Itoa(id))
+		if id < 0 {
+			sb.WriteString(strconv.FormatInt(int64(id), 10))
+		} else {
+			sb.WriteString(strconv.FormatUint(uint64(id), 10))
+		}
 		if index < len(ids)-1 {
 			sb.WriteString(",")
 		}
@@ -17,6 +33,97 @@ func JoinForIn(ids []int) string {
 	return sb.String()
 }
 
+// JoinForInInt64 is the int64 counterpart of JoinForIn.
+//
+// Deprecated: JoinForIn accepts []int64.
+func JoinForInInt64(ids []int64) string {
+	return JoinForIn(ids)
+}
+
+// JoinForInFromModels joins the integer ids of loaded models for an IN list,
+// like JoinForIn: no items give "NULL". It returns "" when T is not registered
+// or has no integer id.
+func JoinForInFromModels[T any](items []*T) string {
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return ""
+	}
+	pos, ok := fieldMap.ColumnsMap["id"]
+	if !ok {
+		return ""
+	}
+	if len(items) == 0 {
+		return "NULL"
+	}
+
+	var sb strings.Builder
+	for index, item := range items {
+		id := fieldMap.field(reflect.ValueOf(item).Elem(), pos)
+		switch id.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			sb.WriteString(strconv.FormatInt(id.Int(), 10))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			sb.WriteString(strconv.FormatUint(id.Uint(), 10))
+		default:
+			return ""
+		}
+		if index < len(items)-1 {
+			sb.WriteString(",")
+		}
+	}
+	return sb.String()
+}
+
+// InClause builds an "IN (...)" clause with placeholders for T's driver,
+// starting after offset existing arguments, and returns the values as args.
+// Empty values give "IN (NULL)", which matches no rows on every database
+// instead of the "IN ()" syntax error.
+func InClause[T any](offset int, values []any) (string, []any) {
+	if len(values) == 0 {
+		return "IN (NULL)", values
+	}
+	return "IN (" + JoinStringForIn[T](offset, make([]string, len(values))) + ")", values
+}
+
+// InClauseStrict is InClause for callers that treat an empty list as a bug: it
+// returns ErrEmptyIn instead of a clause matching nothing.
+func InClauseStrict[T any](offset int, values []any) (string, []any, error) {
+	if len(values) == 0 {
+		return "", nil, ErrEmptyIn
+	}
+	clause, args := InClause[T](offset, values)
+	return clause, args, nil
+}
+
+// InArgs returns the placeholder list for an IN clause over values, numbered
+// after offset existing arguments, together with values as args to append to
+// the query's arguments:
+//
+//	in, inArgs := lit.InArgs(lit.PostgreSQL, 1, emails)
+//	query := "SELECT * FROM users WHERE active = $1 AND email IN (" + in + ")"
+//	users, err := lit.Select[User](db, query, append([]any{true}, inArgs...)...)
+//
+// Empty values give "NULL", like InClause.
+func InArgs[V any](driver Driver, offset int, values []V) (string, []any) {
+	args := make([]any, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	if len(values) == 0 {
+		return "NULL", args
+	}
+	return driver.JoinStringForIn(offset, len(values)), args
+}
+
+// InArgsFor is InArgs with the driver T is registered with.
+func InArgsFor[T any, V any](offset int, values []V) (string, []any) {
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return InArgs(PostgreSQL, offset, values)
+	}
+	return InArgs(fieldMap.Driver, offset, values)
+}
+
 func JoinStringForIn[T any](offset int, params []string) string {
 	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
 	if err != nil {
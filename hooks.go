@@ -0,0 +1,151 @@
+package lit
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// QueryHook observes every statement run through a NewHookedExecutor. The
+// query is the final SQL text, after named parameters were expanded.
+type QueryHook interface {
+	BeforeQuery(ctx context.Context, query string, args []any)
+	AfterQuery(ctx context.Context, query string, args []any, duration time.Duration, err error)
+}
+
+// ChunkHook is implemented by QueryHooks that report the progress of chunked
+// operations such as DeleteChunked. AfterChunk runs once per chunk with the
+// rows the chunk affected and the running total, both -1 when the database
+// driver cannot report affected rows.
+type ChunkHook interface {
+	AfterChunk(ctx context.Context, operation string, table string, affected int64, total int64)
+}
+
+type hookedExecutor struct {
+	ex    Executor
+	hooks []QueryHook
+}
+
+// NewHookedExecutor wraps ex so hooks run around each Exec, Query and QueryRow.
+func NewHookedExecutor(ex Executor, hooks ...QueryHook) Executor {
+	return &hookedExecutor{ex: ex, hooks: hooks}
+}
+
+func (h *hookedExecutor) Exec(query string, args ...any) (result sql.Result, err error) {
+	defer h.run(query, args)(&err)
+	return h.ex.Exec(query, args...)
+}
+
+func (h *hookedExecutor) Query(query string, args ...any) (rows *sql.Rows, err error) {
+	defer h.run(query, args)(&err)
+	return h.ex.Query(query, args...)
+}
+
+func (h *hookedExecutor) QueryRow(query string, args ...any) (row *sql.Row) {
+	var err error
+	defer h.run(query, args)(&err)
+	row = h.ex.QueryRow(query, args...)
+	err = row.Err()
+	return row
+}
+
+// reportChunk passes a chunk's progress to the ChunkHooks of ex, when ex is a
+// NewHookedExecutor, and to the debug logger.
+func reportChunk(ctx context.Context, ex Executor, operation string, table string, affected int64, total int64) {
+	if h, ok := ex.(*hookedExecutor); ok {
+		for _, hook := range h.hooks {
+			if chunkHook, ok := hook.(ChunkHook); ok {
+				chunkHook.AfterChunk(ctx, operation, table, affected, total)
+			}
+		}
+	}
+	if logger := debugLogger.Load(); logger != nil {
+		logger.Info("lit chunk", "operation", operation, "table", table, "affected", affected, "total", total)
+	}
+}
+
+// run calls BeforeQuery and returns the deferred half, which calls AfterQuery
+// with the elapsed time, also when the query panics.
+func (h *hookedExecutor) run(query string, args []any) func(*error) {
+	ctx := context.Background()
+	for _, hook := range h.hooks {
+		hook.BeforeQuery(ctx, query, args)
+	}
+	start := time.Now()
+	return func(err *error) {
+		duration := time.Since(start)
+		for _, hook := range h.hooks {
+			hook.AfterQuery(ctx, query, args, duration, *err)
+		}
+	}
+}
+
+// LoggingHook logs every statement with its arguments and duration, at debug
+// level on success and error level on failure.
+type LoggingHook struct {
+	Logger *slog.Logger
+}
+
+func (l LoggingHook) BeforeQuery(ctx context.Context, query string, args []any) {}
+
+func (l LoggingHook) AfterQuery(ctx context.Context, query string, args []any, duration time.Duration, err error) {
+	logger := l.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if err != nil && err != sql.ErrNoRows {
+		logger.ErrorContext(ctx, "query failed", "query", query, "args", args, "duration", duration, "error", err)
+		return
+	}
+	logger.DebugContext(ctx, "query", "query", query, "args", args, "duration", duration)
+}
+
+// AfterChunk logs the progress of a chunked operation at info level.
+func (l LoggingHook) AfterChunk(ctx context.Context, operation string, table string, affected int64, total int64) {
+	logger := l.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.InfoContext(ctx, "chunk", "operation", operation, "table", table, "affected", affected, "total", total)
+}
+
+// DescribeQuery returns the uppercased statement keyword and the table it
+// targets, e.g. ("SELECT", "users") for "SELECT id FROM users WHERE ...". The
+// table keeps its schema but loses its quotes, and is empty for statements
+// other than SELECT, INSERT, UPDATE and DELETE. Query hooks use it to label
+// statements.
+func DescribeQuery(query string) (operation, table string) {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "", ""
+	}
+	operation = strings.ToUpper(fields[0])
+
+	var marker string
+	switch operation {
+	case "SELECT", "DELETE":
+		marker = "FROM"
+	case "INSERT":
+		marker = "INTO"
+	case "UPDATE":
+		return operation, describedTable(fields, 1)
+	default:
+		return operation, ""
+	}
+	for i, field := range fields {
+		if strings.EqualFold(field, marker) {
+			return operation, describedTable(fields, i+1)
+		}
+	}
+	return operation, ""
+}
+
+func describedTable(fields []string, i int) string {
+	if i >= len(fields) {
+		return ""
+	}
+	name := strings.TrimRight(fields[i], "(;,")
+	return strings.NewReplacer(`"`, "", "`", "").Replace(name)
+}
@@ -0,0 +1,147 @@
+package lit
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingHook struct {
+	before []string
+	after  []string
+	errs   []error
+}
+
+func (r *recordingHook) BeforeQuery(ctx context.Context, query string, args []any) {
+	r.before = append(r.before, query)
+}
+
+func (r *recordingHook) AfterQuery(ctx context.Context, query string, args []any, duration time.Duration, err error) {
+	r.after = append(r.after, query)
+	r.errs = append(r.errs, err)
+}
+
+type panickingExecutor struct {
+	Executor
+}
+
+func (panickingExecutor) Exec(query string, args ...any) (sql.Result, error) {
+	panic("boom")
+}
+
+func TestHookedExecutor(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec("DELETE FROM test_users").
+		WillReturnError(errors.New("locked"))
+
+	hook := &recordingHook{}
+	ex := NewHookedExecutor(db, hook)
+
+	_, err = Select[TestUser](ex, "SELECT id FROM test_users")
+	require.NoError(t, err)
+	err = Delete(ex, "DELETE FROM test_users WHERE id = $1", 1)
+	assert.EqualError(t, err, "locked")
+
+	assert.Equal(t, []string{"SELECT id FROM test_users", "DELETE FROM test_users WHERE id = $1"}, hook.before)
+	assert.Equal(t, hook.before, hook.after)
+	assert.NoError(t, hook.errs[0])
+	assert.EqualError(t, hook.errs[1], "locked")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHookedExecutor_AfterQueryRunsOnPanic(t *testing.T) {
+	hook := &recordingHook{}
+	ex := NewHookedExecutor(panickingExecutor{}, hook)
+
+	assert.Panics(t, func() { _, _ = ex.Exec("DELETE FROM test_users") })
+	assert.Equal(t, []string{"DELETE FROM test_users"}, hook.after)
+}
+
+func TestLoggingHook(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("DELETE FROM test_users").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	ex := NewHookedExecutor(db, LoggingHook{Logger: logger})
+
+	require.NoError(t, Delete(ex, "DELETE FROM test_users WHERE id = $1", 1))
+	assert.Contains(t, buf.String(), `query="DELETE FROM test_users WHERE id = $1"`)
+	assert.Contains(t, buf.String(), "args=[1]")
+	assert.Contains(t, buf.String(), "duration=")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLoggingHook_DeleteChunkedProgress(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](MySQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("DELETE FROM test_users").WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectExec("DELETE FROM test_users").WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectExec("DELETE FROM test_users").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	ex := NewHookedExecutor(db, LoggingHook{Logger: logger})
+
+	total, err := DeleteChunked[TestUser](context.Background(), ex, "email = ?", 10, 0, "x")
+	require.NoError(t, err)
+	assert.Equal(t, int64(14), total)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], "msg=chunk operation=DeleteChunked table=test_users affected=10 total=10")
+	assert.Contains(t, lines[1], "affected=4 total=14")
+	assert.Contains(t, lines[2], "affected=0 total=14")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDescribeQuery(t *testing.T) {
+	cases := []struct {
+		query     string
+		operation string
+		table     string
+	}{
+		{"SELECT id,email FROM users WHERE id = $1", "SELECT", "users"},
+		{`INSERT INTO "order" (id,total) VALUES (DEFAULT,$1) RETURNING id`, "INSERT", "order"},
+		{"UPDATE test_users SET email = ? WHERE id = ?", "UPDATE", "test_users"},
+		{"delete from myapp.users where id = $1", "DELETE", "myapp.users"},
+		{"BEGIN", "BEGIN", ""},
+		{"", "", ""},
+	}
+	for _, c := range cases {
+		operation, table := DescribeQuery(c.query)
+		assert.Equal(t, c.operation, operation, c.query)
+		assert.Equal(t, c.table, table, c.query)
+	}
+}
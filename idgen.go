@@ -0,0 +1,201 @@
+package lit
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdGenerator produces ids for models with a string (or uuid.UUID) id field.
+type IdGenerator interface {
+	NewId() (string, error)
+}
+
+// IdGeneratorFunc adapts a plain function to IdGenerator.
+type IdGeneratorFunc func() (string, error)
+
+func (f IdGeneratorFunc) NewId() (string, error) { return f() }
+
+var uuidGenerator IdGenerator = IdGeneratorFunc(func() (string, error) {
+	if fn, _ := customUuidGenerator.Load().(func() (string, error)); fn != nil {
+		return fn()
+	}
+	id, err := generateUuid()
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+})
+
+// UUID4 generates random version 4 UUIDs, as uuid.New() does, regardless of
+// SetUuidVersion.
+var UUID4 IdGenerator = IdGeneratorFunc(func() (string, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+})
+
+// ULID generates 26 character, lexicographically sortable ULIDs.
+var ULID IdGenerator = IdGeneratorFunc(newULID)
+
+// NanoID generates 21 character, URL-safe random ids.
+var NanoID IdGenerator = IdGeneratorFunc(newNanoID)
+
+// idGenerators holds the generators models can name with the id_generator
+// tag option.
+var (
+	idGeneratorsMu sync.RWMutex
+	idGenerators   = map[string]IdGenerator{
+		"uuid4":  UUID4,
+		"uuid":   uuidGenerator,
+		"ulid":   ULID,
+		"nanoid": NanoID,
+	}
+)
+
+// RegisterIDGenerator makes fn available to models under name, e.g.
+// `lit:"id,id_generator:snowflake"`. "uuid4" (the default for InsertUuid),
+// "uuid" (see SetUuidVersion), "ulid" and "nanoid" are built in; registering
+// one of them again replaces it. It is safe to call while other goroutines
+// insert. Use WithIdGenerator for a generator that can fail.
+func RegisterIDGenerator(name string, fn func() string) {
+	idGeneratorsMu.Lock()
+	defer idGeneratorsMu.Unlock()
+	idGenerators[name] = IdGeneratorFunc(func() (string, error) { return fn(), nil })
+}
+
+// parseIdGeneratorName returns the generator named by an
+// "id_generator=name" option, or "".
+func parseIdGeneratorName(options []string) string {
+	for _, opt := range options {
+		for _, prefix := range []string{"id_generator=", "id_generator:"} {
+			if name, ok := strings.CutPrefix(opt, prefix); ok {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+const crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+func newULID() (string, error) {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(time.Now().UnixMilli())<<16)
+	if _, err := rand.Read(b[6:]); err != nil {
+		return "", err
+	}
+
+	// 128 bits encode to 26 base32 characters, the first one holding 3 bits.
+	hi := binary.BigEndian.Uint64(b[:8])
+	lo := binary.BigEndian.Uint64(b[8:])
+	out := make([]byte, 26)
+	for i := 25; i >= 0; i-- {
+		out[i] = crockfordBase32[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out), nil
+}
+
+const nanoIDAlphabet = "useandom-26T198340PX75pxJACKVERYMINDBUSHWOLF_GQZbfghjklqvwyzrict"
+
+// newNanoID draws 21 symbols from a 64 character alphabet, 126 random bits
+// like the reference nanoid implementation.
+func newNanoID() (string, error) {
+	var b [21]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = nanoIDAlphabet[b[i]&63]
+	}
+	return string(b[:]), nil
+}
+
+// WithIdGenerator sets the generator InsertUuid and InsertWithGenerator (when
+// called with a nil generator) use for the model.
+func WithIdGenerator(gen IdGenerator) ModelOption {
+	return func(fieldMap *FieldMap) {
+		fieldMap.IdGenerator = gen
+	}
+}
+
+// InsertWithGenerator sets a freshly generated id on t and inserts it. A nil
+// gen falls back to the model's WithIdGenerator option, then to the generator
+// named by its id_generator tag option, then to UUIDs.
+func InsertWithGenerator[T any](ex Executor, t *T, gen IdGenerator) (_ string, err error) {
+	defer wrapModelError[T]("InsertWithGenerator", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return "", err
+	}
+
+	if gen == nil {
+		gen = fieldMap.IdGenerator
+	}
+	if gen == nil && fieldMap.IDGeneratorName != "" {
+		idGeneratorsMu.RLock()
+		named, ok := idGenerators[fieldMap.IDGeneratorName]
+		idGeneratorsMu.RUnlock()
+		if !ok {
+			return "", fmt.Errorf("unknown id generator %q, register it with lit.RegisterIDGenerator", fieldMap.IDGeneratorName)
+		}
+		gen = named
+	}
+	if gen == nil {
+		gen = uuidGenerator
+	}
+
+	id, err := gen.NewId()
+	if err != nil {
+		return "", err
+	}
+	if err := setIdField(fieldMap.field(reflect.ValueOf(t).Elem(), fieldMap.ColumnsMap["id"]), id); err != nil {
+		return "", err
+	}
+
+	if err := ValidateColumns[T](fieldMap.InsertColumns, fieldMap); err != nil {
+		return "", err
+	}
+
+	if err := checkOptionalColumnsForWrite(ex, fieldMap); err != nil {
+		return "", err
+	}
+
+	if err := fieldMap.Hooks.beforeInsert(t); err != nil {
+		return "", err
+	}
+
+	setAutoCreateFields(fieldMap, t)
+	applyNormalizers(fieldMap, t)
+
+	insertQuery, insertColumns := insertQueryFor(fieldMap, t)
+	_, err = debugged(ex).Exec(insertQuery, argsForColumns(insertColumns, fieldMap, t)...)
+	if err != nil {
+		return "", err
+	}
+
+	return id, fieldMap.Hooks.afterInsert(t, 0)
+}
+
+// setIdField stores a generated id in a string or uuid.UUID id field.
+func setIdField(field reflect.Value, id string) error {
+	if field.Kind() == reflect.String {
+		field.SetString(id)
+		return nil
+	}
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return fmt.Errorf("cannot store id %q in %s field: %w", id, field.Type(), err)
+	}
+	return setUuidField(field, parsed)
+}
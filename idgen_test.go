@@ -0,0 +1,184 @@
+package lit
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertWithGenerator(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestProduct]())
+	RegisterModel[TestProduct](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO test_products").
+		WithArgs("prod_1", "Widget", 100).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	gen := IdGeneratorFunc(func() (string, error) { return "prod_1", nil })
+	product := &TestProduct{Name: "Widget", Price: 100}
+	id, err := InsertWithGenerator(db, product, gen)
+	require.NoError(t, err)
+	assert.Equal(t, "prod_1", id)
+	assert.Equal(t, "prod_1", product.Id)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertWithGenerator_ModelDefault(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestProduct]())
+	RegisterModelWithOptions[TestProduct](PostgreSQL, WithIdGenerator(ULID))
+	defer delete(StructToFieldMap, reflect.TypeFor[TestProduct]())
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO test_products").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	id, err := InsertWithGenerator(db, &TestProduct{Name: "Widget"}, nil)
+	require.NoError(t, err)
+	assert.Len(t, id, 26)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertWithGenerator_Error(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestProduct]())
+	RegisterModel[TestProduct](PostgreSQL)
+
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	gen := IdGeneratorFunc(func() (string, error) { return "", errors.New("out of ids") })
+	_, err = InsertWithGenerator(db, &TestProduct{Name: "Widget"}, gen)
+	assert.EqualError(t, err, "lit: TestProduct.InsertWithGenerator: out of ids")
+}
+
+func TestInsertWithGenerator_NonUuidIntoUUIDField(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestDevice]())
+	RegisterModel[TestDevice](PostgreSQL)
+
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = InsertWithGenerator(db, &TestDevice{Name: "Sensor"}, ULID)
+	assert.ErrorContains(t, err, "in uuid.UUID field")
+}
+
+func TestULID(t *testing.T) {
+	first, err := ULID.NewId()
+	require.NoError(t, err)
+	second, err := ULID.NewId()
+	require.NoError(t, err)
+
+	assert.Len(t, first, 26)
+	assert.NotEqual(t, first, second)
+	assert.LessOrEqual(t, first[:10], second[:10], "timestamp prefix must not go backwards")
+	for _, c := range first {
+		assert.True(t, strings.ContainsRune(crockfordBase32, c), "unexpected character %q", c)
+	}
+}
+
+func TestNanoID(t *testing.T) {
+	first, err := NanoID.NewId()
+	require.NoError(t, err)
+	second, err := NanoID.NewId()
+	require.NoError(t, err)
+
+	assert.Len(t, first, 21)
+	assert.NotEqual(t, first, second)
+	for _, c := range first {
+		assert.True(t, strings.ContainsRune(nanoIDAlphabet, c), "unexpected character %q", c)
+	}
+}
+
+func TestIdGeneratorTag_BuiltIns(t *testing.T) {
+	type TestNanoInvite struct {
+		Id    string `lit:"id,id_generator:nanoid"`
+		Email string
+	}
+	type TestRandomKey struct {
+		Id   string `lit:"id,id_generator=uuid4"`
+		Name string
+	}
+	RegisterModel[TestNanoInvite](PostgreSQL)
+	defer DeregisterModel[TestNanoInvite]()
+	RegisterModel[TestRandomKey](PostgreSQL)
+	defer DeregisterModel[TestRandomKey]()
+	SetUuidVersion(UUIDv7)
+	defer SetUuidVersion(UUIDv4)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	mock.ExpectExec("INSERT INTO test_nano_invites").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO test_random_keys").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	id, err := InsertUuid(db, &TestNanoInvite{Email: "jane@example.com"})
+	require.NoError(t, err)
+	assert.Len(t, id, 21)
+
+	id, err = InsertUuid(db, &TestRandomKey{Name: "primary"})
+	require.NoError(t, err)
+	parsed, err := uuid.Parse(id)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Version(4), parsed.Version())
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+type TestTaggedDevice struct {
+	Id   string `lit:"id,id_generator=serial"`
+	Name string
+}
+
+func TestIdGeneratorTag(t *testing.T) {
+	RegisterIDGenerator("serial", func() string { return "dev_1" })
+	defer delete(idGenerators, "serial")
+	RegisterModel[TestTaggedDevice](PostgreSQL)
+	defer DeregisterModel[TestTaggedDevice]()
+
+	fieldMap, err := GetFieldMap(reflect.TypeFor[TestTaggedDevice]())
+	require.NoError(t, err)
+	assert.Equal(t, "serial", fieldMap.IDGeneratorName)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO test_tagged_devices").
+		WithArgs("dev_1", "Sensor").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	id, err := InsertUuid(db, &TestTaggedDevice{Name: "Sensor"})
+	require.NoError(t, err)
+	assert.Equal(t, "dev_1", id)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	delete(idGenerators, "serial")
+	_, err = InsertUuid(db, &TestTaggedDevice{Name: "Sensor"})
+	assert.EqualError(t, err, `lit: TestTaggedDevice.InsertUuid: unknown id generator "serial", register it with lit.RegisterIDGenerator`)
+}
+
+func TestIdGeneratorTag_RequiresId(t *testing.T) {
+	type TestBadDevice struct {
+		Id   string
+		Code string `lit:"code,id_generator=ulid"`
+	}
+	assert.PanicsWithValue(t, "id_generator option requires the id column, TestBadDevice.Code is code", func() {
+		RegisterModel[TestBadDevice](PostgreSQL)
+	})
+}
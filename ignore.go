@@ -0,0 +1,67 @@
+package lit
+
+import (
+	"reflect"
+	"strings"
+)
+
+// InsertIgnore inserts t unless that would violate a unique constraint, in
+// which case the row is silently skipped. It uses INSERT IGNORE on MySQL,
+// INSERT OR IGNORE on SQLite and ON CONFLICT DO NOTHING on PostgreSQL and
+// CockroachDB. No id is read back, so only the BeforeInsert hook runs.
+func InsertIgnore[T any](ex Executor, t *T) (err error) {
+	defer wrapModelError[T]("InsertIgnore", &err)
+	_, err = InsertIgnoreAffected(ex, t)
+	return err
+}
+
+// InsertIgnoreAffected is InsertIgnore returning 1 when the row was inserted
+// and 0 when it was skipped, or -1 when the database driver cannot report it.
+func InsertIgnoreAffected[T any](ex Executor, t *T) (_ int64, err error) {
+	defer wrapModelError[T]("InsertIgnoreAffected", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return 0, err
+	}
+
+	if err := ValidateColumns[T](fieldMap.InsertColumns, fieldMap); err != nil {
+		return 0, err
+	}
+
+	if err := checkOptionalColumnsForWrite(ex, fieldMap); err != nil {
+		return 0, err
+	}
+
+	if err := fieldMap.Hooks.beforeInsert(t); err != nil {
+		return 0, err
+	}
+
+	setAutoCreateFields(fieldMap, t)
+	applyNormalizers(fieldMap, t)
+
+	insertQuery, insertColumns := insertQueryFor(fieldMap, t)
+	query, err := insertIgnoreQuery(fieldMap.Driver, insertQuery)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := debugged(ex).Exec(query, argsForColumns(insertColumns, fieldMap, t)...)
+	if err != nil {
+		return 0, err
+	}
+	return rowsAffected(result), nil
+}
+
+// insertIgnoreQuery rewrites a generated INSERT so conflicting rows are
+// skipped. Custom drivers return ErrUnsupportedOperation.
+func insertIgnoreQuery(driver Driver, insertQuery string) (string, error) {
+	switch driver.(type) {
+	case *mysqlDriver:
+		return "INSERT IGNORE" + strings.TrimPrefix(insertQuery, "INSERT"), nil
+	case *sqliteDriver:
+		return "INSERT OR IGNORE" + strings.TrimPrefix(insertQuery, "INSERT"), nil
+	case *pgDriver, *cockroachDriver:
+		return strings.TrimSuffix(insertQuery, returningIdSuffix) + " ON CONFLICT DO NOTHING", nil
+	}
+	return "", ErrUnsupportedOperation
+}
@@ -0,0 +1,55 @@
+package lit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertIgnore(t *testing.T) {
+	for _, tc := range []struct {
+		driver Driver
+		query  string
+	}{
+		{PostgreSQL, "INSERT INTO test_users (id,first_name,last_name,email) VALUES (DEFAULT,$1,$2,$3) ON CONFLICT DO NOTHING"},
+		{CockroachDB, "INSERT INTO test_users (id,first_name,last_name,email) VALUES (DEFAULT,$1,$2,$3) ON CONFLICT DO NOTHING"},
+		{MySQL, "INSERT IGNORE INTO test_users (id,first_name,last_name,email) VALUES (NULL,?,?,?)"},
+		{SQLite, "INSERT OR IGNORE INTO test_users (id,first_name,last_name,email) VALUES (NULL,?,?,?)"},
+	} {
+		t.Run(tc.driver.Name(), func(t *testing.T) {
+			delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+			RegisterModel[TestUser](tc.driver)
+
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectExec(tc.query).
+				WithArgs("John", "Doe", "john@example.com").
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectExec(tc.query).
+				WithArgs("John", "Doe", "john@example.com").
+				WillReturnResult(sqlmock.NewResult(0, 0))
+
+			user := &TestUser{FirstName: "John", LastName: "Doe", Email: "john@example.com"}
+			affected, err := InsertIgnoreAffected(db, user)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), affected)
+
+			require.NoError(t, InsertIgnore(db, user))
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestInsertIgnore_CustomDriver(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](&mockDriver{})
+
+	err := InsertIgnore(nil, &TestUser{FirstName: "John"})
+	assert.ErrorIs(t, err, ErrUnsupportedOperation)
+}
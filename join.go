@@ -0,0 +1,92 @@
+package lit
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// JoinedResult holds one row of SelectJoined, scanned into both models.
+type JoinedResult[A any, B any] struct {
+	Left  *A
+	Right *B
+}
+
+// SelectJoined scans a JOIN of two registered models into a pair per row.
+// Every selected column must be aliased with a_ or b_ followed by a column of
+// A or B respectively:
+//
+//	SELECT u.id AS a_id, u.email AS a_email, p.id AS b_id, p.bio AS b_bio
+//	FROM users u JOIN profiles p ON p.user_id = u.id
+//
+// For a LEFT JOIN, B's fields must be nullable (pointers or sql.Null*).
+func SelectJoined[A any, B any](ex Executor, query string, args ...any) (_ []*JoinedResult[A, B], err error) {
+	defer wrapModelError[A]("SelectJoined", &err)
+	leftMap, err := GetFieldMap(reflect.TypeFor[A]())
+	if err != nil {
+		return nil, err
+	}
+	rightMap, err := GetFieldMap(reflect.TypeFor[B]())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := debugged(ex).Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	// isLeft[i] tells which model column i belongs to, leftColumns and
+	// rightColumns hold the unprefixed names in query order.
+	isLeft := make([]bool, len(columns))
+	var leftColumns, rightColumns []string
+	for i, column := range columns {
+		if name, ok := strings.CutPrefix(column, "a_"); ok {
+			if _, found := leftMap.ColumnsMap[name]; !found {
+				return nil, fmt.Errorf("column %s is not found in %s", column, reflect.TypeFor[A]().Name())
+			}
+			isLeft[i] = true
+			leftColumns = append(leftColumns, name)
+			continue
+		}
+		if name, ok := strings.CutPrefix(column, "b_"); ok {
+			if _, found := rightMap.ColumnsMap[name]; !found {
+				return nil, fmt.Errorf("column %s is not found in %s", column, reflect.TypeFor[B]().Name())
+			}
+			rightColumns = append(rightColumns, name)
+			continue
+		}
+		return nil, fmt.Errorf("column %s must be aliased with an a_ or b_ prefix", column)
+	}
+
+	list := []*JoinedResult[A, B]{}
+	for rows.Next() {
+		var a A
+		var b B
+		leftPointers := *GetPointersForColumns(leftColumns, leftMap, &a)
+		rightPointers := *GetPointersForColumns(rightColumns, rightMap, &b)
+
+		dest := make([]any, len(columns))
+		for i := range columns {
+			if isLeft[i] {
+				dest[i], leftPointers = leftPointers[0], leftPointers[1:]
+			} else {
+				dest[i], rightPointers = rightPointers[0], rightPointers[1:]
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		list = append(list, &JoinedResult[A, B]{Left: &a, Right: &b})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
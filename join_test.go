@@ -0,0 +1,66 @@
+package lit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type TestUserProfile struct {
+	Id     int
+	UserId int
+	Bio    string
+}
+
+func TestSelectJoined(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+	RegisterModel[TestUserProfile](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT u.id AS a_id").
+		WillReturnRows(sqlmock.NewRows([]string{"a_id", "b_id", "a_email", "b_bio"}).
+			AddRow(1, 10, "john@example.com", "Gopher").
+			AddRow(2, 20, "jane@example.com", "DBA"))
+
+	results, err := SelectJoined[TestUser, TestUserProfile](db,
+		"SELECT u.id AS a_id, p.id AS b_id, u.email AS a_email, p.bio AS b_bio FROM test_users u JOIN test_user_profiles p ON p.user_id = u.id")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, &TestUser{Id: 1, Email: "john@example.com"}, results[0].Left)
+	assert.Equal(t, &TestUserProfile{Id: 10, Bio: "Gopher"}, results[0].Right)
+	assert.Equal(t, 2, results[1].Left.Id)
+	assert.Equal(t, "DBA", results[1].Right.Bio)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectJoined_InvalidColumns(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+	RegisterModel[TestUserProfile](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"a_id", "id"}))
+	mock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"a_id", "b_email"}))
+
+	_, err = SelectJoined[TestUser, TestUserProfile](db, "SELECT u.id AS a_id, p.id FROM test_users u JOIN test_user_profiles p ON p.user_id = u.id")
+	assert.EqualError(t, err, "lit: TestUser.SelectJoined: column id must be aliased with an a_ or b_ prefix")
+
+	_, err = SelectJoined[TestUser, TestUserProfile](db, "SELECT u.id AS a_id, u.email AS b_email FROM test_users u JOIN test_user_profiles p ON p.user_id = u.id")
+	assert.EqualError(t, err, "lit: TestUser.SelectJoined: column b_email is not found in TestUserProfile")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
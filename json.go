@@ -0,0 +1,63 @@
+package lit
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// jsonField wraps a field tagged `lit:"...,json"` so it is stored as its JSON
+// encoding and decoded back on scan.
+type jsonField struct {
+	field reflect.Value
+}
+
+func (j jsonField) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		j.field.SetZero()
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into json column", src)
+	}
+	target := reflect.New(j.field.Type())
+	if err := json.Unmarshal(data, target.Interface()); err != nil {
+		return err
+	}
+	j.field.Set(target.Elem())
+	return nil
+}
+
+func (j jsonField) Value() (driver.Value, error) {
+	switch j.field.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Pointer, reflect.Interface:
+		if j.field.IsNil() {
+			return nil, nil
+		}
+	}
+	data, err := json.Marshal(j.field.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// JSONColumn stores V as JSON without a `json` tag option, for callers that
+// prefer an explicit wrapper type: `Settings lit.JSONColumn[Settings]`.
+type JSONColumn[T any] struct {
+	V T
+}
+
+func (j *JSONColumn[T]) Scan(src any) error {
+	return jsonField{reflect.ValueOf(&j.V).Elem()}.Scan(src)
+}
+
+func (j JSONColumn[T]) Value() (driver.Value, error) {
+	return jsonField{reflect.ValueOf(&j.V).Elem()}.Value()
+}
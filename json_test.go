@@ -0,0 +1,135 @@
+package lit
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type TestAddress struct {
+	City string `json:"city"`
+	Zip  string `json:"zip"`
+}
+
+type TestProfile struct {
+	Id       int
+	Settings map[string]string `lit:"settings,json"`
+	Address  TestAddress       `lit:"address,json"`
+	Tags     []string          `lit:"tags,json"`
+}
+
+func TestRegisterModel_JSONFields(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestProfile]())
+	RegisterModel[TestProfile](PostgreSQL)
+
+	fieldMap, err := GetFieldMap(reflect.TypeFor[TestProfile]())
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, fieldMap.JSONFields)
+}
+
+func TestJSONFields_RoundTrip(t *testing.T) {
+	for _, d := range []Driver{PostgreSQL, MySQL, SQLite} {
+		t.Run(d.Name(), func(t *testing.T) {
+			delete(StructToFieldMap, reflect.TypeFor[TestProfile]())
+			RegisterModel[TestProfile](d)
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			args := []driver.Value{`{"theme":"dark"}`, `{"city":"Berlin","zip":"10115"}`, nil}
+			if d == PostgreSQL {
+				mock.ExpectQuery("INSERT INTO test_profiles").
+					WithArgs(args...).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			} else {
+				mock.ExpectExec("INSERT INTO test_profiles").
+					WithArgs(args...).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+			mock.ExpectQuery("SELECT").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "settings", "address", "tags"}).
+					AddRow(1, []byte(`{"theme":"dark"}`), `{"city":"Berlin","zip":"10115"}`, nil))
+
+			profile := &TestProfile{
+				Settings: map[string]string{"theme": "dark"},
+				Address:  TestAddress{City: "Berlin", Zip: "10115"},
+			}
+			_, err = Insert(db, profile)
+			require.NoError(t, err)
+
+			loaded, err := SelectSingle[TestProfile](db, "SELECT * FROM test_profiles")
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"theme": "dark"}, loaded.Settings)
+			assert.Equal(t, TestAddress{City: "Berlin", Zip: "10115"}, loaded.Address)
+			assert.Nil(t, loaded.Tags)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestJSONFields_Update(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestProfile]())
+	RegisterModel[TestProfile](MySQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE test_profiles SET").
+		WithArgs(1, nil, `{"city":"","zip":""}`, `["a","b"]`, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = Update(db, &TestProfile{Id: 1, Tags: []string{"a", "b"}}, "id = ?", 1)
+	require.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestJSONField_ScanInvalid(t *testing.T) {
+	var settings map[string]string
+	field := jsonField{reflect.ValueOf(&settings).Elem()}
+
+	assert.Error(t, field.Scan(42))
+	assert.Error(t, field.Scan("{not json"))
+
+	settings = map[string]string{"a": "b"}
+	require.NoError(t, field.Scan(nil))
+	assert.Nil(t, settings)
+}
+
+func TestJSONColumn(t *testing.T) {
+	type TestPreferences struct {
+		Id    int
+		Prefs JSONColumn[TestAddress]
+		Extra JSONColumn[map[string]int]
+	}
+	RegisterModel[TestPreferences](SQLite)
+	defer delete(StructToFieldMap, reflect.TypeFor[TestPreferences]())
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO test_preferencess").
+		WithArgs(`{"city":"Paris","zip":"75001"}`, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "prefs", "extra"}).
+			AddRow(1, `{"city":"Paris","zip":"75001"}`, []byte(`{"a":1}`)))
+
+	_, err = Insert(db, &TestPreferences{Prefs: JSONColumn[TestAddress]{V: TestAddress{City: "Paris", Zip: "75001"}}})
+	require.NoError(t, err)
+
+	loaded, err := SelectSingle[TestPreferences](db, "SELECT * FROM test_preferencess")
+	require.NoError(t, err)
+	assert.Equal(t, "Paris", loaded.Prefs.V.City)
+	assert.Equal(t, map[string]int{"a": 1}, loaded.Extra.V)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
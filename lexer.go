@@ -0,0 +1,153 @@
+package lit
+
+import "strings"
+
+// sqlLexer finds the parts of a query that must be copied verbatim when
+// rewriting its parameters or placeholders: string literals, quoted
+// identifiers, comments and, on PostgreSQL, dollar-quoted strings.
+type sqlLexer struct {
+	backslashEscape bool
+	hashComments    bool
+	postgres        bool // dollar quotes, and ? is a JSON operator
+}
+
+func newSQLLexer(driver Driver) sqlLexer {
+	lexer := sqlLexer{backslashEscape: driver.SupportsBackslashEscape()}
+	switch driver.(type) {
+	case *mysqlDriver:
+		lexer.hashComments = true
+	case *pgDriver, *cockroachDriver:
+		lexer.postgres = true
+	}
+	return lexer
+}
+
+// skip returns the index just past the literal or comment starting at
+// runes[i], or -1 if none starts there. Unterminated ones run to the end of
+// the query.
+func (l sqlLexer) skip(runes []rune, i int) int {
+	r := runes[i]
+	next := rune(0)
+	if i+1 < len(runes) {
+		next = runes[i+1]
+	}
+
+	switch {
+	case r == '\'' || r == '"':
+		return l.quoted(runes, i, l.backslashEscape)
+	case r == '`':
+		return l.quoted(runes, i, false)
+	case (r == '-' && next == '-') || (r == '#' && l.hashComments):
+		// Line comment, up to and including the newline
+		for i < len(runes) && runes[i] != '\n' {
+			i++
+		}
+		return min(i+1, len(runes))
+	case r == '/' && next == '*':
+		// Block comment, allowing nested /* */ like PostgreSQL
+		depth := 0
+		for i < len(runes) {
+			if runes[i] == '/' && i+1 < len(runes) && runes[i+1] == '*' {
+				depth++
+				i += 2
+				continue
+			}
+			if runes[i] == '*' && i+1 < len(runes) && runes[i+1] == '/' {
+				depth--
+				i += 2
+				if depth == 0 {
+					return i
+				}
+				continue
+			}
+			i++
+		}
+		return len(runes)
+	case r == '$' && l.postgres:
+		return pgDollarQuoteEnd(runes, i)
+	}
+	return -1
+}
+
+// quoted returns the index just past the string or identifier opened by the
+// quote at runes[i]. A doubled quote is an escaped one, and so is a quote
+// after a backslash when backslashEscape is set (MySQL).
+func (l sqlLexer) quoted(runes []rune, i int, backslashEscape bool) int {
+	quote := runes[i]
+	for i++; i < len(runes); i++ {
+		if backslashEscape && runes[i] == '\\' && i+1 < len(runes) {
+			i++
+			continue
+		}
+		if runes[i] == quote {
+			if i+1 < len(runes) && runes[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(runes)
+}
+
+// code calls fn with the index of every rune of query outside string
+// literals, quoted identifiers and comments.
+func (l sqlLexer) code(runes []rune, fn func(i int)) {
+	for i := 0; i < len(runes); i++ {
+		if end := l.skip(runes, i); end != -1 {
+			i = end - 1
+			continue
+		}
+		fn(i)
+	}
+}
+
+// placeholder is a bind placeholder found by scanPlaceholders: the rune index
+// it starts at and its number, or 0 for a positional ?.
+type placeholder struct {
+	at int
+	n  int
+}
+
+// scanPlaceholders returns the placeholders of query in driver's style (? or
+// numbered, e.g. $1 or :1), skipping the ones inside literals and comments and
+// telling $1 from $10.
+func scanPlaceholders(driver Driver, query string) []placeholder {
+	runes := []rune(query)
+	found := []placeholder{}
+	positional := driver.Placeholder(1) == driver.Placeholder(2)
+	prefix := []rune(strings.TrimSuffix(driver.Placeholder(1), "1"))
+	newSQLLexer(driver).code(runes, func(i int) {
+		if positional {
+			if runes[i] == '?' {
+				found = append(found, placeholder{at: i})
+			}
+			return
+		}
+		if !hasRunesAt(runes, i, prefix) || (i > 0 && (isParamChar(runes[i-1]) || runes[i-1] == prefix[0])) {
+			return
+		}
+		j := i + len(prefix)
+		n := 0
+		for j < len(runes) && runes[j] >= '0' && runes[j] <= '9' {
+			n = n*10 + int(runes[j]-'0')
+			j++
+		}
+		if j > i+len(prefix) {
+			found = append(found, placeholder{at: i, n: n})
+		}
+	})
+	return found
+}
+
+func hasRunesAt(runes []rune, i int, prefix []rune) bool {
+	if len(prefix) == 0 || i+len(prefix) > len(runes) {
+		return false
+	}
+	for k, r := range prefix {
+		if runes[i+k] != r {
+			return false
+		}
+	}
+	return true
+}
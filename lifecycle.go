@@ -0,0 +1,72 @@
+package lit
+
+import "reflect"
+
+// Hooks are model lifecycle callbacks, see RegisterModelWithHooks. Any of them
+// may be nil; a non-nil error aborts the operation and is returned as is.
+type Hooks struct {
+	// Called with the model pointer before the INSERT runs. CopyInsert only
+	// runs this hook, not AfterInsert.
+	BeforeInsert func(t any) error
+	// Called after the INSERT with the generated id (0 for non-integer ids).
+	AfterInsert func(t any, id int) error
+	// Called with the model pointer before the UPDATE runs.
+	BeforeUpdate func(t any) error
+	AfterUpdate  func(t any) error
+	// Called with the id by DeleteById and DeleteModel.
+	BeforeDelete func(id any) error
+	AfterDelete  func(id any) error
+}
+
+// WithHooks sets the model's lifecycle hooks.
+func WithHooks(hooks Hooks) ModelOption {
+	return func(fieldMap *FieldMap) {
+		fieldMap.Hooks = hooks
+	}
+}
+
+func RegisterModelWithHooks[T any](driver Driver, hooks Hooks) error {
+	return registerModel(reflect.TypeFor[T](), driver, DefaultDbNamingStrategy{}, WithHooks(hooks))
+}
+
+func (h Hooks) beforeInsert(t any) error {
+	if h.BeforeInsert == nil {
+		return nil
+	}
+	return h.BeforeInsert(t)
+}
+
+func (h Hooks) afterInsert(t any, id int) error {
+	if h.AfterInsert == nil {
+		return nil
+	}
+	return h.AfterInsert(t, id)
+}
+
+func (h Hooks) beforeUpdate(t any) error {
+	if h.BeforeUpdate == nil {
+		return nil
+	}
+	return h.BeforeUpdate(t)
+}
+
+func (h Hooks) afterUpdate(t any) error {
+	if h.AfterUpdate == nil {
+		return nil
+	}
+	return h.AfterUpdate(t)
+}
+
+func (h Hooks) beforeDelete(id any) error {
+	if h.BeforeDelete == nil {
+		return nil
+	}
+	return h.BeforeDelete(id)
+}
+
+func (h Hooks) afterDelete(id any) error {
+	if h.AfterDelete == nil {
+		return nil
+	}
+	return h.AfterDelete(id)
+}
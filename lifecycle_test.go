@@ -0,0 +1,96 @@
+package lit
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type TestArticle struct {
+	Id    int
+	Title string
+	Slug  string
+}
+
+func TestRegisterModelWithHooks(t *testing.T) {
+	var calls []string
+	var insertedId int
+	RegisterModelWithHooks[TestArticle](PostgreSQL, Hooks{
+		BeforeInsert: func(t any) error {
+			article := t.(*TestArticle)
+			article.Slug = strings.ToLower(strings.ReplaceAll(article.Title, " ", "-"))
+			calls = append(calls, "before insert")
+			return nil
+		},
+		AfterInsert: func(_ any, id int) error {
+			calls = append(calls, "after insert")
+			insertedId = id
+			return nil
+		},
+		BeforeUpdate: func(t any) error {
+			calls = append(calls, "before update")
+			return nil
+		},
+		AfterUpdate: func(t any) error {
+			calls = append(calls, "after update")
+			return nil
+		},
+		BeforeDelete: func(id any) error {
+			calls = append(calls, "before delete")
+			return nil
+		},
+		AfterDelete: func(id any) error {
+			calls = append(calls, "after delete")
+			return nil
+		},
+	})
+	defer delete(StructToFieldMap, reflect.TypeFor[TestArticle]())
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("INSERT INTO test_articles").
+		WithArgs("Hello World", "hello-world").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectExec("UPDATE test_articles").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM test_articles").
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	article := &TestArticle{Title: "Hello World"}
+	_, err = Insert(db, article)
+	require.NoError(t, err)
+	article.Id = 3
+	require.NoError(t, UpdateById(db, article))
+	require.NoError(t, DeleteById[TestArticle](db, 3))
+
+	assert.Equal(t, 3, insertedId)
+	assert.Equal(t, []string{
+		"before insert", "after insert",
+		"before update", "after update",
+		"before delete", "after delete",
+	}, calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHooks_BeforeInsertErrorAborts(t *testing.T) {
+	RegisterModelWithHooks[TestArticle](PostgreSQL, Hooks{
+		BeforeInsert: func(t any) error { return errors.New("title required") },
+	})
+	defer delete(StructToFieldMap, reflect.TypeFor[TestArticle]())
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = Insert(db, &TestArticle{})
+	assert.EqualError(t, err, "lit: TestArticle.Insert: title required")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
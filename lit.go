@@ -4,7 +4,10 @@ import (
 	"database/sql"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 	"unicode"
 )
 
@@ -35,6 +38,14 @@ type Driver interface {
 	// Generate comma-separated placeholders for IN clauses.
 	// PG: "$3,$4,$5" (offset-aware). MySQL/SQLite: "?,?,?" (offset ignored).
 	JoinStringForIn(offset int, count int) string
+
+	// Suffix for a shared row lock. PG: "FOR SHARE". MySQL: "LOCK IN SHARE MODE".
+	// Empty when the database has no row-level locking (SQLite).
+	ForShareClause() string
+
+	// Normalize a time.Time before it is written and after it is scanned,
+	// e.g. forcing UTC and stripping the monotonic clock reading.
+	NormalizeTime(t time.Time) time.Time
 }
 
 type Executor interface {
@@ -58,6 +69,24 @@ func (d DefaultDbNamingStrategy) GetColumnNameFromStructName(input string) strin
 	return toSnakeCase(input)
 }
 
+// LiteralNamingStrategy keeps struct field names as column names (FirstName ->
+// FirstName) and lowercases struct names for tables (User -> users). The Id
+// field still maps to the id column lit uses as primary key. Note that
+// PostgreSQL folds unquoted identifiers to lowercase; only reserved words are
+// quoted.
+type LiteralNamingStrategy struct{}
+
+func (LiteralNamingStrategy) GetTableNameFromStructName(input string) string {
+	return strings.ToLower(input) + "s"
+}
+
+func (LiteralNamingStrategy) GetColumnNameFromStructName(input string) string {
+	if input == "Id" {
+		return "id"
+	}
+	return input
+}
+
 // toSnakeCase converts a CamelCase string to snake_case, keeping consecutive
 // uppercase letters together as acronyms (e.g., "HTTPRequest" -> "http_request").
 func toSnakeCase(input string) string {
@@ -87,14 +116,173 @@ func toSnakeCase(input string) string {
 	return result.String()
 }
 
+// SchemaAwareNamingStrategy prefixes the table names of an inner naming
+// strategy with a schema, e.g. "myapp.users".
+type SchemaAwareNamingStrategy struct {
+	Schema string
+	Inner  DbNamingStrategy
+}
+
+func NewSchemaStrategy(schema string, inner DbNamingStrategy) DbNamingStrategy {
+	return SchemaAwareNamingStrategy{Schema: schema, Inner: inner}
+}
+
+func (s SchemaAwareNamingStrategy) GetTableNameFromStructName(input string) string {
+	return s.Schema + "." + s.Inner.GetTableNameFromStructName(input)
+}
+
+func (s SchemaAwareNamingStrategy) GetColumnNameFromStructName(input string) string {
+	return s.Inner.GetColumnNameFromStructName(input)
+}
+
+// PrefixedNamingStrategy prepends a prefix to the table names of an inner
+// naming strategy, e.g. "tenant_42_users". A schema qualifier from the inner
+// strategy stays in front: "myapp.tenant_42_users".
+type PrefixedNamingStrategy struct {
+	Prefix string
+	Inner  DbNamingStrategy
+}
+
+func NewPrefixedStrategy(prefix string, inner DbNamingStrategy) DbNamingStrategy {
+	return PrefixedNamingStrategy{Prefix: prefix, Inner: inner}
+}
+
+func (s PrefixedNamingStrategy) GetTableNameFromStructName(input string) string {
+	return prefixTableName(s.Prefix, s.Inner.GetTableNameFromStructName(input))
+}
+
+func (s PrefixedNamingStrategy) GetColumnNameFromStructName(input string) string {
+	return s.Inner.GetColumnNameFromStructName(input)
+}
+
+// DynamicPrefixedNamingStrategy is a PrefixedNamingStrategy whose prefix comes
+// from a function, e.g. reading an environment variable. Table names are
+// resolved once at registration, so the function is called then.
+type DynamicPrefixedNamingStrategy struct {
+	Prefix func() string
+	Inner  DbNamingStrategy
+}
+
+func (s DynamicPrefixedNamingStrategy) GetTableNameFromStructName(input string) string {
+	return prefixTableName(s.Prefix(), s.Inner.GetTableNameFromStructName(input))
+}
+
+func (s DynamicPrefixedNamingStrategy) GetColumnNameFromStructName(input string) string {
+	return s.Inner.GetColumnNameFromStructName(input)
+}
+
+func prefixTableName(prefix string, tableName string) string {
+	if dot := strings.LastIndex(tableName, "."); dot >= 0 {
+		return tableName[:dot+1] + prefix + tableName[dot+1:]
+	}
+	return prefix + tableName
+}
+
+// FuncNamingStrategy builds a naming strategy from two functions. A nil
+// function falls back to DefaultDbNamingStrategy.
+type FuncNamingStrategy struct {
+	TableNameFunc  func(string) string
+	ColumnNameFunc func(string) string
+}
+
+func (s FuncNamingStrategy) GetTableNameFromStructName(input string) string {
+	if s.TableNameFunc == nil {
+		return DefaultDbNamingStrategy{}.GetTableNameFromStructName(input)
+	}
+	return s.TableNameFunc(input)
+}
+
+func (s FuncNamingStrategy) GetColumnNameFromStructName(input string) string {
+	if s.ColumnNameFunc == nil {
+		return DefaultDbNamingStrategy{}.GetColumnNameFromStructName(input)
+	}
+	return s.ColumnNameFunc(input)
+}
+
+// escapeTableName escapes only the table part of a possibly schema-qualified
+// name, leaving the schema prefix and the dot untouched.
+func escapeTableName(tableName string, escape func(string) string) string {
+	if dot := strings.LastIndex(tableName, "."); dot >= 0 {
+		return tableName[:dot+1] + escape(tableName[dot+1:])
+	}
+	return escape(tableName)
+}
+
 type FieldMap struct {
-	ColumnsMap    map[string]int
-	ColumnKeys    []string
+	// Column name to position in ColumnKeys and FieldIndexes. The []int field
+	// lists below hold the same positions.
+	ColumnsMap map[string]int
+	ColumnKeys []string
+	// Struct field index chains by column position, see reflect.Value.FieldByIndex.
+	FieldIndexes [][]int
+
 	HasIntId      bool
 	InsertQuery   string
 	UpdateQuery   string
 	InsertColumns []string
+	UpdateColumns []string
 	Driver        Driver
+	TableName     string
+
+	// Columns that may not exist yet during a rolling deploy, see WithOptionalColumns.
+	OptionalColumns []string
+	optionalProbe   *columnProbe
+
+	// Escaped ORDER BY clause for generated selects, see WithDefaultOrder.
+	DefaultOrder string
+	defaultOrder []orderTerm
+
+	// Field positions tagged `lit:"...,autocreate"`, set to the current time
+	// on insert when still zero.
+	AutoCreateFields []int
+	// Field positions tagged `lit:"...,autoupdate"`, set to the current time
+	// on every update.
+	AutoUpdateFields []int
+	// Position of the field tagged `lit:"created_at,auto"`, stamped on
+	// insert, or -1.
+	AutoCreatedAtIdx int
+	// Position of the field tagged `lit:"updated_at,auto"`, stamped on insert
+	// and update, or -1.
+	AutoUpdatedAtIdx int
+	// Normalizer chains by field position, from `lit:"...,normalize=lower|trim"`.
+	Normalizers map[int][]func(string) string
+	// Column tagged `lit:"...,softdelete"`, set by SoftDelete and filtered by SelectActive.
+	SoftDeleteColumn string
+	// Columns tagged `lit:"...,default"`, left out of INSERT while zero so the
+	// database default applies.
+	DefaultColumns []string
+	// Field positions tagged `lit:"...,json"`, stored as their JSON encoding.
+	JSONFields []int
+	// Field positions tagged `lit:"...,omitempty"`, skipped by UpdateOmitEmpty
+	// while zero.
+	OmitEmptyFields []int
+	// Slice field positions stored in PostgreSQL array columns.
+	ArrayFields []int
+	// Plain time.Time field positions, passed through Driver.NormalizeTime.
+	TimeFields []int
+	// Layouts by field position, from `lit:"...,time_format=2006-01-02"`.
+	TimeFormats map[int]string
+	// Generator for InsertWithGenerator, see WithIdGenerator.
+	IdGenerator IdGenerator
+	// Name of a RegisterIDGenerator generator, from `lit:"id,id_generator:ulid"`.
+	IDGeneratorName string
+	// Id field is a uuid.UUID, or tagged `lit:"id,uuid"` or
+	// `lit:"id,id_generator=uuid"`. CockroachDB fills it with gen_random_uuid()
+	// when left empty.
+	HasUuidId bool
+	// Lifecycle callbacks, see RegisterModelWithHooks.
+	Hooks Hooks
+	// Database the model lives in, see RegisterModelWithDB. Nil when callers
+	// always pass an Executor.
+	DefaultDB *sql.DB
+
+	writableColumns    []string
+	fieldPointers      func(t any, field int) any
+	directFields       []bool
+	scanner            func(rows *sql.Rows, t any) error
+	scanColumns        []string
+	defaultInsertCache *sync.Map
+	partialUpdateCache *sync.Map
 }
 
 type InsertUpdateQueryGenerator interface {
@@ -109,59 +297,342 @@ func RegisterDriver(driver Driver) {
 	defaultDriver = driver
 }
 
-func RegisterModel[T any](driver ...Driver) {
-	var d Driver
+// MustRegisterDriver is RegisterDriver for init functions: it panics if
+// driver is nil.
+func MustRegisterDriver(driver Driver) {
+	if driver == nil {
+		panic("lit: MustRegisterDriver called with a nil driver")
+	}
+	RegisterDriver(driver)
+}
+
+// RegisterModel registers T with driver, or with the RegisterDriver default
+// when none is given. Registering T again with the same driver replaces the
+// registration; with another driver it fails with ErrAlreadyRegistered and
+// keeps the first one, see RegisterModelForce.
+func RegisterModel[T any](driver ...Driver) error {
+	return RegisterModelWithNaming[T](resolveDriver(driver), DefaultDbNamingStrategy{})
+}
+
+// MustRegisterModel is RegisterModel for init functions: it panics if the
+// registration fails.
+func MustRegisterModel[T any](driver ...Driver) {
+	if err := RegisterModel[T](driver...); err != nil {
+		panic(fmt.Sprintf("lit: cannot register %s: %v", reflect.TypeFor[T]().Name(), err))
+	}
+}
+
+// RegisterModelForce registers T with driver, replacing any registration T
+// already has.
+func RegisterModelForce[T any](driver ...Driver) {
+	forceRegisterModel(reflect.TypeFor[T](), resolveDriver(driver), DefaultDbNamingStrategy{})
+}
+
+// RegisterModelIfAbsent registers T with driver unless T is already
+// registered, and reports whether it did.
+func RegisterModelIfAbsent[T any](driver ...Driver) bool {
+	if _, ok := StructToFieldMap[reflect.TypeFor[T]()]; ok {
+		return false
+	}
+	RegisterModelForce[T](driver...)
+	return true
+}
+
+// RegisterModels registers several models with driver at once:
+//
+//	lit.RegisterModels(lit.PostgreSQL, lit.ModelRegistrar[User](), lit.ModelRegistrar[Product]())
+func RegisterModels(driver Driver, registrars ...func(Driver)) {
+	for _, register := range registrars {
+		register(driver)
+	}
+}
+
+// ModelRegistrar returns a func registering T with the driver it is given, for
+// RegisterModels. It panics like MustRegisterModel if the registration fails.
+func ModelRegistrar[T any]() func(Driver) {
+	return func(d Driver) {
+		MustRegisterModel[T](d)
+	}
+}
+
+func resolveDriver(driver []Driver) Driver {
 	if len(driver) > 0 {
-		d = driver[0]
-	} else if defaultDriver != nil {
-		d = defaultDriver
-	} else {
-		panic("no driver provided and no default driver set.")
+		return driver[0]
+	}
+	if defaultDriver != nil {
+		return defaultDriver
 	}
-	RegisterModelWithNaming[T](d, DefaultDbNamingStrategy{})
+	panic("no driver provided and no default driver set.")
+}
+
+func RegisterModelWithNaming[T any](driver Driver, namingStrategy DbNamingStrategy) error {
+	return registerModel(reflect.TypeFor[T](), driver, namingStrategy)
+}
+
+// RegisterModelWithFuncs registers T with table and column names computed by
+// the given functions, see FuncNamingStrategy.
+func RegisterModelWithFuncs[T any](driver Driver, tableFunc func(string) string, columnFunc func(string) string) error {
+	return RegisterModelWithNaming[T](driver, FuncNamingStrategy{TableNameFunc: tableFunc, ColumnNameFunc: columnFunc})
+}
+
+// ModelOption customizes a model's FieldMap at registration time.
+type ModelOption func(*FieldMap)
+
+func RegisterModelWithOptions[T any](driver Driver, opts ...ModelOption) error {
+	return registerModel(reflect.TypeFor[T](), driver, DefaultDbNamingStrategy{}, opts...)
 }
 
-func RegisterModelWithNaming[T any](driver Driver, namingStrategy DbNamingStrategy) {
+// RegisterModelScoped registers T like RegisterModel and returns a function
+// that undoes it, restoring any registration T had before. It is meant for
+// tests: t.Cleanup(lit.RegisterModelScoped[User](lit.PostgreSQL)).
+func RegisterModelScoped[T any](driver Driver) (deregister func()) {
 	t := reflect.TypeFor[T]()
+	previous, existed := StructToFieldMap[t]
+	RegisterModelForce[T](driver)
+	return func() {
+		if existed {
+			StructToFieldMap[t] = previous
+		} else {
+			delete(StructToFieldMap, t)
+		}
+	}
+}
+
+// DeregisterModel removes the registration of T, if any.
+func DeregisterModel[T any]() {
+	delete(StructToFieldMap, reflect.TypeFor[T]())
+}
+
+// DeregisterAll removes every registered model.
+func DeregisterAll() {
+	clear(StructToFieldMap)
+}
+
+func registerModel(t reflect.Type, driver Driver, namingStrategy DbNamingStrategy, opts ...ModelOption) error {
+	if existing, ok := StructToFieldMap[t]; ok && existing.Driver != driver {
+		return fmt.Errorf("%w: %s is registered with %s, use RegisterModelForce to replace it", ErrAlreadyRegistered, t.Name(), existing.Driver.Name())
+	}
+	forceRegisterModel(t, driver, namingStrategy, opts...)
+	return nil
+}
+
+func forceRegisterModel(t reflect.Type, driver Driver, namingStrategy DbNamingStrategy, opts ...ModelOption) {
 
 	columnsMap := make(map[string]int)
 	columnKeys := []string{}
+	writableKeys := []string{}
 	hasIntId := false
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		name := field.Tag.Get("lit")
+	hasUuidId := false
+	autoCreateFields := []int{}
+	autoUpdateFields := []int{}
+	autoCreatedAtIdx := -1
+	autoUpdatedAtIdx := -1
+	fieldNormalizers := map[int][]func(string) string{}
+	softDeleteColumn := ""
+	idGeneratorName := ""
+	defaultColumns := []string{}
+	jsonFields := []int{}
+	omitEmptyFields := []int{}
+	arrayFields := []int{}
+	timeFields := []int{}
+	timeFormats := map[int]string{}
+	fieldIndexes := [][]int{}
+	for i, field := range structFields(t, nil) {
+		name, options := parseLitTag(field.Tag.Get("lit"))
 		if name == "" {
 			name = namingStrategy.GetColumnNameFromStructName(field.Name)
 		}
+		if slices.Contains(options, "auto") {
+			// Shorthand: created_at is stamped on insert, any other column
+			// (updated_at) on insert and update.
+			if name == "created_at" {
+				autoCreatedAtIdx = i
+				options = append(options, "autocreate")
+			} else {
+				if autoUpdatedAtIdx >= 0 {
+					panic(fmt.Sprintf("auto option is set on more than one updated column, %s.%s is the second", t.Name(), field.Name))
+				}
+				autoUpdatedAtIdx = i
+				options = append(options, "autocreate", "autoupdate")
+			}
+		}
+		if slices.Contains(options, "autocreate") {
+			if field.Type != timeType {
+				panic(fmt.Sprintf("autocreate option requires a time.Time field, %s.%s is %s", t.Name(), field.Name, field.Type))
+			}
+			autoCreateFields = append(autoCreateFields, i)
+		}
+		if slices.Contains(options, "autoupdate") {
+			if field.Type != timeType {
+				panic(fmt.Sprintf("autoupdate option requires a time.Time field, %s.%s is %s", t.Name(), field.Name, field.Type))
+			}
+			autoUpdateFields = append(autoUpdateFields, i)
+		}
+		if slices.Contains(options, "default") {
+			defaultColumns = append(defaultColumns, name)
+		}
+		isJSON := slices.Contains(options, "json")
+		isArray := slices.Contains(options, "array")
+		if isArray && (field.Type.Kind() != reflect.Slice || !isArrayElemKind(field.Type.Elem().Kind())) {
+			panic(fmt.Sprintf("array option requires a slice of strings, numbers or bools, %s.%s is %s", t.Name(), field.Name, field.Type))
+		}
+		if !isJSON && usesPgArrays(driver) && field.Type.Kind() == reflect.Slice && isArrayElemKind(field.Type.Elem().Kind()) {
+			arrayFields = append(arrayFields, i)
+		} else if isJSON || isArray {
+			// Without native arrays, array fields fall back to the JSON encoding.
+			jsonFields = append(jsonFields, i)
+		}
+		if layout := parseTimeFormat(options); layout != "" {
+			if field.Type != timeType {
+				panic(fmt.Sprintf("time_format option requires a time.Time field, %s.%s is %s", t.Name(), field.Name, field.Type))
+			}
+			timeFormats[i] = layout
+		} else if field.Type == timeType && !isJSON {
+			timeFields = append(timeFields, i)
+		}
+		if slices.Contains(options, "omitempty") {
+			omitEmptyFields = append(omitEmptyFields, i)
+		}
+		if generator := parseIdGeneratorName(options); generator != "" {
+			if name != "id" {
+				panic(fmt.Sprintf("id_generator option requires the id column, %s.%s is %s", t.Name(), field.Name, name))
+			}
+			idGeneratorName = generator
+		}
+		if slices.Contains(options, "softdelete") {
+			softDeleteColumn = name
+		}
+		chain, err := parseNormalizers(options)
+		if err != nil {
+			panic(fmt.Sprintf("%s.%s: %s", t.Name(), field.Name, err))
+		}
+		if len(chain) > 0 {
+			if field.Type.Kind() != reflect.String {
+				panic(fmt.Sprintf("normalize option requires a string field, %s.%s is %s", t.Name(), field.Name, field.Type))
+			}
+			fieldNormalizers[i] = chain
+		}
 		if name == "id" {
 			if field.Type.AssignableTo(reflect.TypeOf(0)) {
 				hasIntId = true
 			}
+			if field.Type == uuidType || slices.Contains(options, "uuid") || idGeneratorName == "uuid" || idGeneratorName == "uuid4" {
+				hasUuidId = true
+			}
 		}
 		columnKeys = append(columnKeys, name)
 		columnsMap[name] = i
+		fieldIndexes = append(fieldIndexes, field.Index)
+		if !slices.Contains(options, "readonly") {
+			writableKeys = append(writableKeys, name)
+		}
 	}
 
 	tableName := namingStrategy.GetTableNameFromStructName(t.Name())
 
-	insertQuery, insertColumns := driver.GenerateInsertQuery(tableName, columnKeys, hasIntId)
-	updateQuery := driver.GenerateUpdateQuery(tableName, columnKeys)
+	insertQuery, insertColumns := generateInsertQuery(driver, tableName, writableKeys, hasIntId, hasUuidId)
+	updateQuery := driver.GenerateUpdateQuery(tableName, writableKeys)
 
-	StructToFieldMap[t] = &FieldMap{
+	fieldMap := &FieldMap{
 		ColumnsMap:    columnsMap,
 		ColumnKeys:    columnKeys,
+		FieldIndexes:  fieldIndexes,
 		HasIntId:      hasIntId,
 		InsertQuery:   insertQuery,
 		UpdateQuery:   updateQuery,
 		InsertColumns: insertColumns,
+		UpdateColumns: writableKeys,
 		Driver:        driver,
+		TableName:     tableName,
+
+		AutoCreateFields: autoCreateFields,
+		AutoUpdateFields: autoUpdateFields,
+		AutoCreatedAtIdx: autoCreatedAtIdx,
+		AutoUpdatedAtIdx: autoUpdatedAtIdx,
+		Normalizers:      fieldNormalizers,
+		SoftDeleteColumn: softDeleteColumn,
+		DefaultColumns:   defaultColumns,
+		JSONFields:       jsonFields,
+		OmitEmptyFields:  omitEmptyFields,
+		ArrayFields:      arrayFields,
+		TimeFields:       timeFields,
+		TimeFormats:      timeFormats,
+		IDGeneratorName:  idGeneratorName,
+		HasUuidId:        hasUuidId,
+
+		writableColumns:    writableKeys,
+		fieldPointers:      fieldPointerFuncs[t],
+		defaultInsertCache: &sync.Map{},
+		partialUpdateCache: &sync.Map{},
 	}
+	for _, opt := range opts {
+		opt(fieldMap)
+	}
+	fieldMap.directFields = directFields(t, fieldMap)
+	fieldMap.scanner, fieldMap.scanColumns = bindScanner(t, fieldMap, scannerFuncs[t])
+
+	StructToFieldMap[t] = fieldMap
+}
+
+// ExcludeIdFromUpdate leaves the id column out of the generated UPDATE SET
+// list, so Update binds only the other columns before the WHERE arguments.
+func ExcludeIdFromUpdate() ModelOption {
+	return func(fieldMap *FieldMap) {
+		columns := slices.DeleteFunc(slices.Clone(fieldMap.UpdateColumns), func(column string) bool {
+			return column == "id"
+		})
+		fieldMap.UpdateColumns = columns
+		fieldMap.UpdateQuery = fieldMap.Driver.GenerateUpdateQuery(fieldMap.TableName, columns)
+	}
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// structFields lists the fields of t with embedded structs flattened in place,
+// their Index holding the full chain from t. As with Go's promotion rules, a
+// shallower field shadows a deeper one of the same name. Embedded structs with
+// a lit tag are kept as a single column.
+func structFields(t reflect.Type, index []int) []reflect.StructField {
+	fields := []reflect.StructField{}
+	positions := map[string]int{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		field.Index = append(slices.Clone(index), i)
+
+		candidates := []reflect.StructField{field}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Type != timeType && field.Tag.Get("lit") == "" {
+			candidates = structFields(field.Type, field.Index)
+		}
+		for _, candidate := range candidates {
+			if pos, ok := positions[candidate.Name]; ok {
+				if len(candidate.Index) < len(fields[pos].Index) {
+					fields[pos] = candidate
+				}
+				continue
+			}
+			positions[candidate.Name] = len(fields)
+			fields = append(fields, candidate)
+		}
+	}
+	return fields
+}
+
+// field returns the struct field of v stored in the column at pos.
+func (f *FieldMap) field(v reflect.Value, pos int) reflect.Value {
+	return v.FieldByIndex(f.FieldIndexes[pos])
+}
+
+// parseLitTag splits a `lit` struct tag into the column name and its options,
+// e.g. `lit:"created_at,autocreate"` -> ("created_at", ["autocreate"]).
+func parseLitTag(tag string) (string, []string) {
+	parts := strings.Split(tag, ",")
+	return strings.TrimSpace(parts[0]), parts[1:]
 }
 
 func GetFieldMap(t reflect.Type) (*FieldMap, error) {
 	val, ok := StructToFieldMap[t]
 	if !ok {
-		return nil, fmt.Errorf("non registered model %s used. Please call `lit.RegisterModel[%s](driver)` after you define %s", t.Name(), t.Name(), t.Name())
+		return nil, NotRegisteredError{TypeName: t.Name()}
 	}
 	return val, nil
 }
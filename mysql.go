@@ -2,6 +2,7 @@ package lit
 
 import (
 	"strings"
+	"time"
 )
 
 type mysqlDriver struct{}
@@ -16,7 +17,7 @@ func (d *mysqlDriver) GenerateInsertQuery(tableName string, columnKeys []string,
 	var insertQuery strings.Builder
 
 	insertQuery.WriteString("INSERT INTO ")
-	insertQuery.WriteString(mysqlEscapeReserved(tableName))
+	insertQuery.WriteString(escapeTableName(tableName, mysqlEscapeReserved))
 	insertQuery.WriteString(" (")
 
 	totalKeys := len(columnKeys)
@@ -49,7 +50,7 @@ func (d *mysqlDriver) GenerateInsertQuery(tableName string, columnKeys []string,
 func (d *mysqlDriver) GenerateUpdateQuery(tableName string, columnKeys []string) string {
 	var updateQuery strings.Builder
 	updateQuery.WriteString("UPDATE ")
-	updateQuery.WriteString(mysqlEscapeReserved(tableName))
+	updateQuery.WriteString(escapeTableName(tableName, mysqlEscapeReserved))
 	updateQuery.WriteString(" SET ")
 
 	totalKeys := len(columnKeys)
@@ -67,7 +68,7 @@ func (d *mysqlDriver) GenerateUpdateQuery(tableName string, columnKeys []string)
 }
 
 func (d *mysqlDriver) InsertAndGetId(ex Executor, query string, args ...any) (int, error) {
-	result, err := ex.Exec(query, args...)
+	result, err := debugged(ex).Exec(query, args...)
 	if err != nil {
 		return 0, err
 	}
@@ -92,6 +93,18 @@ func (d *mysqlDriver) JoinStringForIn(offset int, count int) string {
 	return mysqlJoinStringForIn(count)
 }
 
+func (d *mysqlDriver) ForShareClause() string { return "LOCK IN SHARE MODE" }
+
+// NormalizeTime keeps the zero time as is, since MySQL reads zero datetimes
+// ("0000-00-00 00:00:00") back as the zero time, and truncates to the
+// microsecond precision of DATETIME(6).
+func (d *mysqlDriver) NormalizeTime(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return t.Round(0).UTC().Truncate(time.Microsecond)
+}
+
 // Deprecated: Use MySQL variable directly. MySqlInsertUpdateQueryGenerator is kept for backward compatibility.
 type MySqlInsertUpdateQueryGenerator = mysqlDriver
 
@@ -0,0 +1,88 @@
+package lit
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	normalizersMu sync.RWMutex
+	normalizers   = map[string]func(string) string{
+		"lower":          strings.ToLower,
+		"upper":          strings.ToUpper,
+		"trim":           strings.TrimSpace,
+		"collapse_space": collapseSpace,
+	}
+)
+
+// RegisterNormalizer adds a named normalizer usable in `lit:"...,normalize=name"`
+// tags. It must be called before the models that use it are registered, and
+// is safe to call concurrently with registration.
+func RegisterNormalizer(name string, fn func(string) string) {
+	normalizersMu.Lock()
+	defer normalizersMu.Unlock()
+	normalizers[name] = fn
+}
+
+// parseNormalizers resolves the normalizer chain of a tag. Chains are written
+// as one option with the names separated by |, e.g. the options of
+// `lit:"email,normalize=lower|trim"` resolve to [lower, trim].
+func parseNormalizers(options []string) ([]func(string) string, error) {
+	normalizersMu.RLock()
+	defer normalizersMu.RUnlock()
+
+	var chain []func(string) string
+	for _, option := range options {
+		names, ok := strings.CutPrefix(strings.TrimSpace(option), "normalize=")
+		if !ok {
+			continue
+		}
+		for _, name := range strings.Split(names, "|") {
+			fn, ok := normalizers[strings.TrimSpace(name)]
+			if !ok {
+				return nil, fmt.Errorf("unknown normalizer %q", name)
+			}
+			chain = append(chain, fn)
+		}
+	}
+	return chain, nil
+}
+
+// applyNormalizers rewrites the normalized string fields of t in place, so the
+// stored value and the struct stay in sync.
+func applyNormalizers[T any](fieldMap *FieldMap, t *T) {
+	if len(fieldMap.Normalizers) == 0 {
+		return
+	}
+	v := reflect.ValueOf(t).Elem()
+	for pos, chain := range fieldMap.Normalizers {
+		value := fieldMap.field(v, pos).String()
+		for _, fn := range chain {
+			value = fn(value)
+		}
+		fieldMap.field(v, pos).SetString(value)
+	}
+}
+
+// normalizeChanges normalizes the string values of an UpdateMap changes map
+// whose columns carry a normalizer chain.
+func normalizeChanges(fieldMap *FieldMap, changes map[string]any) {
+	for pos, chain := range fieldMap.Normalizers {
+		column := fieldMap.ColumnKeys[pos]
+		value := reflect.ValueOf(changes[column])
+		if value.Kind() != reflect.String {
+			continue
+		}
+		normalized := value.String()
+		for _, fn := range chain {
+			normalized = fn(normalized)
+		}
+		changes[column] = normalized
+	}
+}
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
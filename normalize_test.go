@@ -0,0 +1,129 @@
+package lit
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type TestNormalizedUser struct {
+	Id    int
+	Email string `lit:"email,normalize=lower|trim"`
+	Name  string `lit:",normalize=collapse_space"`
+	Code  string `lit:"code,normalize=upper"`
+}
+
+func TestParseNormalizers(t *testing.T) {
+	chain, err := parseNormalizers([]string{"normalize=lower|trim"})
+	require.NoError(t, err)
+	assert.Len(t, chain, 2)
+
+	chain, err = parseNormalizers([]string{"normalize=lower", "trim", "upper"})
+	require.NoError(t, err)
+	assert.Len(t, chain, 1)
+
+	chain, err = parseNormalizers([]string{"autocreate"})
+	require.NoError(t, err)
+	assert.Empty(t, chain)
+
+	_, err = parseNormalizers([]string{"normalize=rot13"})
+	assert.Error(t, err)
+}
+
+func TestRegisterModel_Normalizers(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestNormalizedUser]())
+	RegisterModel[TestNormalizedUser](PostgreSQL)
+
+	fieldMap, err := GetFieldMap(reflect.TypeFor[TestNormalizedUser]())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "email", "name", "code"}, fieldMap.ColumnKeys)
+	assert.Len(t, fieldMap.Normalizers[1], 2)
+	assert.Len(t, fieldMap.Normalizers[2], 1)
+}
+
+func TestRegisterModel_Normalizers_NonStringField_Panics(t *testing.T) {
+	type BadNormalized struct {
+		Id    int
+		Count int `lit:"count,normalize=trim"`
+	}
+
+	assert.Panics(t, func() {
+		RegisterModel[BadNormalized](PostgreSQL)
+	})
+}
+
+func TestUpdateMap_Normalizers(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestNormalizedUser]())
+	RegisterModel[TestNormalizedUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(`UPDATE test_normalized_users SET code = $1,email = $2 WHERE id = $3`).
+		WithArgs("AB1", "jane@example.com", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	changes := map[string]any{"email": "  Jane@Example.COM ", "code": "ab1"}
+	_, err = UpdateMap[TestNormalizedUser](db, changes, "id = $1", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "ab1", changes["code"])
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsert_Normalizers_PostgreSQL(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestNormalizedUser]())
+	RegisterModel[TestNormalizedUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("INSERT INTO test_normalized_users").
+		WithArgs("john@example.com", "John Smith", "ABC").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	user := &TestNormalizedUser{Email: "  John@Example.COM ", Name: " John   Smith ", Code: "abc"}
+	_, err = Insert(db, user)
+	require.NoError(t, err)
+	assert.Equal(t, "john@example.com", user.Email)
+	assert.Equal(t, "John Smith", user.Name)
+	assert.Equal(t, "ABC", user.Code)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdate_Normalizers_CustomNormalizer_MySQL(t *testing.T) {
+	type TestSluggedPost struct {
+		Id   int
+		Slug string `lit:"slug,normalize=trim|slug"`
+	}
+
+	RegisterNormalizer("slug", func(s string) string {
+		return strings.ReplaceAll(strings.ToLower(s), " ", "-")
+	})
+	defer delete(normalizers, "slug")
+
+	RegisterModel[TestSluggedPost](MySQL)
+	defer delete(StructToFieldMap, reflect.TypeFor[TestSluggedPost]())
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE test_slugged_posts SET").
+		WithArgs(1, "hello-world", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	post := &TestSluggedPost{Id: 1, Slug: " Hello World "}
+	err = Update(db, post, "id = ?", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "hello-world", post.Slug)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,104 @@
+package lit
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// nullableField wraps a pointer field (*string, *int, *int64, *float64, *bool,
+// *time.Time, ...) so a NULL column scans into nil. Writes pass the pointer
+// itself, see argsForColumns.
+type nullableField struct {
+	field reflect.Value
+}
+
+func (n nullableField) Scan(src any) error {
+	if src == nil {
+		n.field.SetZero()
+		return nil
+	}
+	elem := reflect.New(n.field.Type().Elem())
+	if scanner, ok := elem.Interface().(sql.Scanner); ok {
+		if err := scanner.Scan(src); err != nil {
+			return err
+		}
+	} else if err := assignScanned(elem.Elem(), src); err != nil {
+		return err
+	}
+	n.field.Set(elem)
+	return nil
+}
+
+// assignScanned stores a value coming from the database driver (int64,
+// float64, bool, []byte, string or time.Time) into dst.
+func assignScanned(dst reflect.Value, src any) error {
+	sv := reflect.ValueOf(src)
+	if sv.Type().AssignableTo(dst.Type()) {
+		dst.Set(sv)
+		return nil
+	}
+
+	var text string
+	switch v := src.(type) {
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	default:
+		text = fmt.Sprint(src)
+	}
+
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(text)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if sv.CanInt() {
+			dst.SetInt(sv.Int())
+			return nil
+		}
+		i, err := strconv.ParseInt(text, 10, dst.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("cannot scan %T into %s: %w", src, dst.Type(), err)
+		}
+		dst.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(text, 10, dst.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("cannot scan %T into %s: %w", src, dst.Type(), err)
+		}
+		dst.SetUint(u)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		if sv.CanFloat() {
+			dst.SetFloat(sv.Float())
+			return nil
+		}
+		f, err := strconv.ParseFloat(text, dst.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("cannot scan %T into %s: %w", src, dst.Type(), err)
+		}
+		dst.SetFloat(f)
+		return nil
+	case reflect.Bool:
+		if sv.CanInt() {
+			dst.SetBool(sv.Int() != 0)
+			return nil
+		}
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return fmt.Errorf("cannot scan %T into %s: %w", src, dst.Type(), err)
+		}
+		dst.SetBool(b)
+		return nil
+	}
+
+	if sv.Type().ConvertibleTo(dst.Type()) {
+		dst.Set(sv.Convert(dst.Type()))
+		return nil
+	}
+	return fmt.Errorf("cannot scan %T into %s", src, dst.Type())
+}
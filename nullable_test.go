@@ -0,0 +1,136 @@
+package lit
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type TestNullableUser struct {
+	Id        int
+	Nickname  *string
+	Age       *int
+	Score     *float64
+	Active    *bool
+	Visits    *int64
+	LastLogin *time.Time
+}
+
+func TestSelect_NullableFields(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestNullableUser]())
+	RegisterModel[TestNullableUser](MySQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	login := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	mock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "nickname", "age", "score", "active", "visits", "last_login"}).
+			AddRow(1, []byte("johnny"), int64(42), []byte("9.5"), int64(1), int64(7), login).
+			AddRow(2, nil, nil, nil, nil, nil, nil))
+
+	users, err := Select[TestNullableUser](db, "SELECT * FROM test_nullable_users")
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+
+	require.NotNil(t, users[0].Nickname)
+	assert.Equal(t, "johnny", *users[0].Nickname)
+	assert.Equal(t, 42, *users[0].Age)
+	assert.Equal(t, 9.5, *users[0].Score)
+	assert.True(t, *users[0].Active)
+	assert.Equal(t, int64(7), *users[0].Visits)
+	assert.Equal(t, login, *users[0].LastLogin)
+
+	assert.Nil(t, users[1].Nickname)
+	assert.Nil(t, users[1].Age)
+	assert.Nil(t, users[1].Score)
+	assert.Nil(t, users[1].Active)
+	assert.Nil(t, users[1].Visits)
+	assert.Nil(t, users[1].LastLogin)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsert_NullableFields(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestNullableUser]())
+	RegisterModel[TestNullableUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	nickname := "johnny"
+	age := 42
+	mock.ExpectQuery("INSERT INTO test_nullable_users").
+		WithArgs("johnny", int64(42), nil, nil, nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	_, err = Insert(db, &TestNullableUser{Nickname: &nickname, Age: &age})
+	require.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+type testPoint struct {
+	X, Y int
+}
+
+// pointConverter stands in for a database driver that accepts *testPoint
+// arguments natively, without testPoint implementing driver.Valuer.
+type pointConverter struct{}
+
+func (pointConverter) ConvertValue(v any) (driver.Value, error) {
+	if p, ok := v.(*testPoint); ok {
+		if p == nil {
+			return nil, nil
+		}
+		return fmt.Sprintf("(%d,%d)", p.X, p.Y), nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
+type TestNullableShape struct {
+	Id     int
+	Center *testPoint
+}
+
+func TestInsert_NullableFields_DriverType(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestNullableShape]())
+	RegisterModel[TestNullableShape](PostgreSQL)
+
+	db, mock, err := sqlmock.New(sqlmock.ValueConverterOption(pointConverter{}))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("INSERT INTO test_nullable_shapes").
+		WithArgs("(1,2)").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("INSERT INTO test_nullable_shapes").
+		WithArgs(nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+
+	_, err = Insert(db, &TestNullableShape{Center: &testPoint{1, 2}})
+	require.NoError(t, err)
+	_, err = Insert(db, &TestNullableShape{})
+	require.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNullableField_ScanInvalid(t *testing.T) {
+	var age *int
+	field := nullableField{reflect.ValueOf(&age).Elem()}
+
+	assert.Error(t, field.Scan([]byte("not a number")))
+	assert.Nil(t, age)
+
+	require.NoError(t, field.Scan("12"))
+	assert.Equal(t, 12, *age)
+}
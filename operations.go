@@ -1,9 +1,17 @@
 package lit
 
 import (
+	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"maps"
 	"reflect"
 	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -22,13 +30,190 @@ func GetPointersForColumns[T any](columns []string, fieldMap *FieldMap, t *T) *[
 
 	for _, column := range columns {
 		pos := fieldMap.ColumnsMap[column]
-		dest = append(dest, reflect.ValueOf(t).Elem().Field(pos).Addr().Interface())
+		if fieldMap.fieldPointers != nil && fieldMap.directFields[pos] {
+			if p := fieldMap.fieldPointers(t, fieldMap.FieldIndexes[pos][0]); p != nil {
+				dest = append(dest, p)
+				continue
+			}
+		}
+		field := fieldMap.field(reflect.ValueOf(t).Elem(), pos)
+		if slices.Contains(fieldMap.JSONFields, pos) {
+			dest = append(dest, jsonField{field})
+			continue
+		}
+		if slices.Contains(fieldMap.ArrayFields, pos) {
+			dest = append(dest, pgArrayField{field})
+			continue
+		}
+		if slices.Contains(fieldMap.TimeFields, pos) {
+			dest = append(dest, timeField{field, fieldMap.Driver})
+			continue
+		}
+		if layout, ok := fieldMap.TimeFormats[pos]; ok {
+			dest = append(dest, timeStringScanner{field, layout})
+			continue
+		}
+		if field.Kind() == reflect.Pointer {
+			dest = append(dest, nullableField{field})
+			continue
+		}
+		dest = append(dest, field.Addr().Interface())
 	}
 	return &dest
 }
 
-func Select[T any](ex Executor, query string, args ...any) ([]*T, error) {
-	rows, err := ex.Query(query, args...)
+// argsForColumns is GetPointersForColumns for statement arguments. Nullable
+// pointer fields are passed as they are, leaving NULL and driver-specific
+// types to the database driver.
+func argsForColumns[T any](columns []string, fieldMap *FieldMap, t *T) []any {
+	args := *GetPointersForColumns(columns, fieldMap, t)
+	for i, arg := range args {
+		if nullable, ok := arg.(nullableField); ok {
+			args[i] = nullable.field.Interface()
+		}
+	}
+	return args
+}
+
+var autoTimestampLocation atomic.Pointer[time.Location]
+
+// SetAutoTimestampLocation sets the location of the times written to
+// autocreate and autoupdate fields, time.UTC by default. A nil loc restores
+// UTC. Drivers still normalize plain time.Time columns when writing them, see
+// Driver.NormalizeTime.
+func SetAutoTimestampLocation(loc *time.Location) {
+	autoTimestampLocation.Store(loc)
+}
+
+// autoTimestamp returns the current time in the SetAutoTimestampLocation
+// location.
+func autoTimestamp() time.Time {
+	if loc := autoTimestampLocation.Load(); loc != nil {
+		return time.Now().In(loc)
+	}
+	return time.Now().UTC()
+}
+
+// setAutoCreateFields stamps every autocreate field that is still zero with
+// the current time. It must run before the insert arguments are collected.
+func setAutoCreateFields[T any](fieldMap *FieldMap, t *T) {
+	if len(fieldMap.AutoCreateFields) == 0 {
+		return
+	}
+	now := reflect.ValueOf(autoTimestamp())
+	v := reflect.ValueOf(t).Elem()
+	for _, pos := range fieldMap.AutoCreateFields {
+		if fieldMap.field(v, pos).IsZero() {
+			fieldMap.field(v, pos).Set(now)
+		}
+	}
+}
+
+// setAutoUpdateFields stamps every autoupdate field with the current time.
+// It must run before the update arguments are collected.
+func setAutoUpdateFields[T any](ex Executor, fieldMap *FieldMap, t *T) {
+	if len(fieldMap.AutoUpdateFields) == 0 {
+		return
+	}
+	if _, skip := ex.(skipAutoUpdateExecutor); skip {
+		return
+	}
+	now := reflect.ValueOf(autoTimestamp())
+	v := reflect.ValueOf(t).Elem()
+	for _, pos := range fieldMap.AutoUpdateFields {
+		fieldMap.field(v, pos).Set(now)
+	}
+}
+
+// setAutoUpdateChanges adds the current time to changes for every writable
+// autoupdate column the caller did not set, the map counterpart of
+// setAutoUpdateFields.
+func setAutoUpdateChanges(ex Executor, fieldMap *FieldMap, changes map[string]any) {
+	if len(fieldMap.AutoUpdateFields) == 0 {
+		return
+	}
+	if _, skip := ex.(skipAutoUpdateExecutor); skip {
+		return
+	}
+	now := autoTimestamp()
+	for _, pos := range fieldMap.AutoUpdateFields {
+		column := fieldMap.ColumnKeys[pos]
+		if _, set := changes[column]; set || !slices.Contains(fieldMap.UpdateColumns, column) {
+			continue
+		}
+		if layout, ok := fieldMap.TimeFormats[pos]; ok {
+			changes[column] = now.Format(layout)
+		} else {
+			changes[column] = fieldMap.Driver.NormalizeTime(now)
+		}
+	}
+}
+
+type skipAutoUpdateExecutor struct {
+	Executor
+}
+
+// SkipAutoUpdate wraps ex so that updates made through it leave autoupdate
+// fields untouched, e.g. for data migrations that must keep the original
+// timestamps.
+func SkipAutoUpdate(ex Executor) Executor {
+	return skipAutoUpdateExecutor{ex}
+}
+
+// insertQueryFor returns the INSERT statement and its columns for t. Columns
+// tagged `lit:"...,default"` that hold their zero value are left out so the
+// database default applies; each combination is generated once and cached.
+func insertQueryFor[T any](fieldMap *FieldMap, t *T) (string, []string) {
+	return insertQueryWith(fieldMap, t, nil)
+}
+
+// insertQueryWith is insertQueryFor keeping the default columns in values,
+// whose value is given separately, see InsertNamed.
+func insertQueryWith[T any](fieldMap *FieldMap, t *T, values map[string]any) (string, []string) {
+	if len(fieldMap.DefaultColumns) == 0 {
+		return fieldMap.InsertQuery, fieldMap.InsertColumns
+	}
+
+	v := reflect.ValueOf(t).Elem()
+	key := make([]byte, len(fieldMap.DefaultColumns))
+	omitted := false
+	for i, column := range fieldMap.DefaultColumns {
+		key[i] = '0'
+		if _, given := values[column]; !given && fieldMap.field(v, fieldMap.ColumnsMap[column]).IsZero() {
+			key[i] = '1'
+			omitted = true
+		}
+	}
+	if !omitted {
+		return fieldMap.InsertQuery, fieldMap.InsertColumns
+	}
+
+	if cached, ok := fieldMap.defaultInsertCache.Load(string(key)); ok {
+		q := cached.(*insertStatement)
+		return q.query, q.columns
+	}
+
+	columns := []string{}
+	for _, column := range fieldMap.writableColumns {
+		pos := slices.Index(fieldMap.DefaultColumns, column)
+		if pos >= 0 && key[pos] == '1' {
+			continue
+		}
+		columns = append(columns, column)
+	}
+	query, insertColumns := generateInsertQuery(fieldMap.Driver, fieldMap.TableName, columns, fieldMap.HasIntId, fieldMap.HasUuidId)
+	fieldMap.defaultInsertCache.Store(string(key), &insertStatement{query: query, columns: insertColumns})
+	return query, insertColumns
+}
+
+type insertStatement struct {
+	query   string
+	columns []string
+}
+
+func Select[T any](ex Executor, query string, args ...any) (_ []*T, err error) {
+	defer wrapModelError[T]("Select", &err)
+	rows, err := debugged(ex).Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -52,7 +237,7 @@ func Select[T any](ex Executor, query string, args ...any) ([]*T, error) {
 
 	for rows.Next() {
 		var t T
-		if err := rows.Scan(*GetPointersForColumns[T](columns, fieldMap, &t)...); err != nil {
+		if err := scanRow(rows, columns, fieldMap, &t); err != nil {
 			return nil, err
 		}
 		list = append(list, &t)
@@ -63,7 +248,47 @@ func Select[T any](ex Executor, query string, args ...any) ([]*T, error) {
 	return list, nil
 }
 
-func SelectSingle[T any](ex Executor, query string, args ...any) (*T, error) {
+// SelectAppend is Select appending value-typed rows to dst, reusing its
+// capacity instead of allocating a new slice and one T per row. Pass dst[:0]
+// to refill the same backing array on every call.
+func SelectAppend[T any](ex Executor, dst []T, query string, args ...any) (_ []T, err error) {
+	defer wrapModelError[T]("SelectAppend", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return dst, err
+	}
+
+	rows, err := debugged(ex).Query(query, args...)
+	if err != nil {
+		return dst, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return dst, err
+	}
+
+	if err := ValidateColumns[T](columns, fieldMap); err != nil {
+		return dst, err
+	}
+
+	start := len(dst)
+	var zero T
+	for rows.Next() {
+		dst = append(dst, zero)
+		if err := scanRow(rows, columns, fieldMap, &dst[len(dst)-1]); err != nil {
+			return dst[:start], err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return dst[:start], err
+	}
+	return dst, nil
+}
+
+func SelectSingle[T any](ex Executor, query string, args ...any) (_ *T, err error) {
+	defer wrapModelError[T]("SelectSingle", &err)
 	l, err := Select[T](ex, query, args...)
 	if err != nil {
 		return nil, err
@@ -74,7 +299,81 @@ func SelectSingle[T any](ex Executor, query string, args ...any) (*T, error) {
 	return nil, nil
 }
 
-func Insert[T any](ex Executor, t *T) (int, error) {
+// SelectSingleOrNotFound is like SelectSingle but returns ErrNotFound instead
+// of (nil, nil) when no row matches.
+func SelectSingleOrNotFound[T any](ex Executor, query string, args ...any) (_ *T, err error) {
+	defer wrapModelError[T]("SelectSingleOrNotFound", &err)
+	t, err := SelectSingle[T](ex, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrNotFound
+	}
+	return t, nil
+}
+
+// SelectSingleStrict is like SelectSingleOrNotFound, but the ErrNotFound it
+// returns names the model, e.g. "lit: no rows found: User".
+func SelectSingleStrict[T any](ex Executor, query string, args ...any) (_ *T, err error) {
+	defer wrapModelError[T]("SelectSingleStrict", &err)
+	t, err := SelectSingle[T](ex, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, reflect.TypeFor[T]().Name())
+	}
+	return t, nil
+}
+
+// SelectExactlyOne returns the only row matched by query. It returns
+// ErrNotFound when no row matches and ErrMultipleRows when more than one does,
+// reading at most two rows either way.
+func SelectExactlyOne[T any](ex Executor, query string, args ...any) (_ *T, err error) {
+	defer wrapModelError[T]("SelectExactlyOne", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := debugged(ex).Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateColumns[T](columns, fieldMap); err != nil {
+		return nil, err
+	}
+
+	name := reflect.TypeFor[T]().Name()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	var t T
+	if err := scanRow(rows, columns, fieldMap, &t); err != nil {
+		return nil, err
+	}
+	if rows.Next() {
+		return nil, fmt.Errorf("%w: %s", ErrMultipleRows, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func Insert[T any](ex Executor, t *T) (_ int, err error) {
+	defer wrapModelError[T]("Insert", &err)
 	tType := reflect.TypeOf(*t)
 	fieldMap, err := GetFieldMap(tType)
 	if err != nil {
@@ -85,81 +384,601 @@ func Insert[T any](ex Executor, t *T) (int, error) {
 		return 0, err
 	}
 
-	pointers := *GetPointersForColumns(fieldMap.InsertColumns, fieldMap, t)
+	if err := checkOptionalColumnsForWrite(ex, fieldMap); err != nil {
+		return 0, err
+	}
+
+	if err := fieldMap.Hooks.beforeInsert(t); err != nil {
+		return 0, err
+	}
+
+	setAutoCreateFields(fieldMap, t)
+	applyNormalizers(fieldMap, t)
+
+	insertQuery, insertColumns := insertQueryFor(fieldMap, t)
+	pointers := argsForColumns(insertColumns, fieldMap, t)
+
+	if fieldMap.HasUuidId && returnsInsertedId(fieldMap.Driver) {
+		// The UUID, possibly generated by the database, is read back into t.
+		idField := fieldMap.field(reflect.ValueOf(t).Elem(), fieldMap.ColumnsMap["id"])
+		if err := debugged(ex).QueryRow(insertQuery, pointers...).Scan(idField.Addr().Interface()); err != nil {
+			return 0, err
+		}
+		return 0, fieldMap.Hooks.afterInsert(t, 0)
+	}
 
-	return fieldMap.Driver.InsertAndGetId(ex, fieldMap.InsertQuery, pointers...)
+	id, err := fieldMap.Driver.InsertAndGetId(ex, insertQuery, pointers...)
+	if err != nil {
+		return 0, err
+	}
+	return id, fieldMap.Hooks.afterInsert(t, id)
 }
 
-func InsertUuid[T any](ex Executor, t *T) (string, error) {
+// returnsInsertedId reports whether the driver's insert queries end in
+// RETURNING id.
+func returnsInsertedId(driver Driver) bool {
+	switch driver.(type) {
+	case *pgDriver, *cockroachDriver:
+		return true
+	}
+	return false
+}
+
+// InsertUuid sets a new UUID on t and inserts it. Models with a generator
+// from WithIdGenerator or the id_generator tag option get their id from it
+// instead.
+func InsertUuid[T any](ex Executor, t *T) (_ string, err error) {
+	defer wrapModelError[T]("InsertUuid", &err)
+	return InsertWithGenerator(ex, t, nil)
+}
+
+func InsertExistingUuid[T any](ex Executor, t *T) (err error) {
+	defer wrapModelError[T]("InsertExistingUuid", &err)
 	tType := reflect.TypeOf(*t)
 	fieldMap, err := GetFieldMap(tType)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	newUuid, err := uuid.NewUUID()
-	if err != nil {
-		panic(err)
+	if err := checkExistingUuid(fieldMap.field(reflect.ValueOf(t).Elem(), fieldMap.ColumnsMap["id"])); err != nil {
+		return err
 	}
-	newUuidString := newUuid.String()
-	reflect.ValueOf(t).Elem().Field(fieldMap.ColumnsMap["id"]).SetString(newUuidString)
 
 	if err := ValidateColumns[T](fieldMap.InsertColumns, fieldMap); err != nil {
-		return "", err
+		return err
+	}
+
+	if err := checkOptionalColumnsForWrite(ex, fieldMap); err != nil {
+		return err
+	}
+
+	if err := fieldMap.Hooks.beforeInsert(t); err != nil {
+		return err
 	}
 
-	_, err = ex.Exec(fieldMap.InsertQuery, *GetPointersForColumns[T](fieldMap.InsertColumns, fieldMap, t)...)
+	setAutoCreateFields(fieldMap, t)
+	applyNormalizers(fieldMap, t)
+
+	insertQuery, insertColumns := insertQueryFor(fieldMap, t)
+	_, err = debugged(ex).Exec(insertQuery, argsForColumns(insertColumns, fieldMap, t)...)
 	if err != nil {
-		return "", err
+		return err
 	}
+	return fieldMap.Hooks.afterInsert(t, 0)
+}
 
-	return newUuidString, nil
+var uuidBytesType = reflect.TypeFor[[16]byte]()
+
+// setUuidField stores id in a string or 16-byte (e.g. uuid.UUID) id field.
+func setUuidField(field reflect.Value, id uuid.UUID) error {
+	switch {
+	case field.Kind() == reflect.String:
+		field.SetString(id.String())
+	case field.Type().ConvertibleTo(uuidBytesType) && field.Kind() == reflect.Array:
+		field.Set(reflect.ValueOf([16]byte(id)).Convert(field.Type()))
+	default:
+		return fmt.Errorf("uuid id field must be a string or uuid.UUID, got %s", field.Type())
+	}
+	return nil
 }
 
-func InsertExistingUuid[T any](ex Executor, t *T) error {
+// checkExistingUuid rejects a zero id, i.e. "", the nil UUID or a zero
+// uuid.UUID, and string ids that are not UUIDs.
+func checkExistingUuid(field reflect.Value) error {
+	if field.Kind() != reflect.String {
+		if field.IsZero() {
+			return errors.New("InsertExistingUuid requires a non-zero id")
+		}
+		return nil
+	}
+	if field.String() == "" {
+		return errors.New("InsertExistingUuid requires a non-zero id")
+	}
+	id, err := uuid.Parse(field.String())
+	if err != nil {
+		return fmt.Errorf("InsertExistingUuid requires a UUID id, got %q", field.String())
+	}
+	if id == uuid.Nil {
+		return errors.New("InsertExistingUuid requires a non-zero id")
+	}
+	return nil
+}
+
+func Update[T any](ex Executor, t *T, where string, args ...any) (err error) {
+	defer wrapModelError[T]("Update", &err)
+	_, err = UpdateAffected(ex, t, where, args...)
+	return err
+}
+
+// UpdateAffected is Update returning the number of affected rows, or -1 when
+// the database driver cannot report it.
+func UpdateAffected[T any](ex Executor, t *T, where string, args ...any) (_ int64, err error) {
+	defer wrapModelError[T]("UpdateAffected", &err)
+	if len(where) == 0 {
+		return 0, errors.New("parameter 'where' was not present")
+	}
 	tType := reflect.TypeOf(*t)
 	fieldMap, err := GetFieldMap(tType)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := ValidateColumns[T](fieldMap.UpdateColumns, fieldMap); err != nil {
+		return 0, err
+	}
+
+	if err := checkOptionalColumnsForWrite(ex, fieldMap); err != nil {
+		return 0, err
+	}
+
+	if err := fieldMap.Hooks.beforeUpdate(t); err != nil {
+		return 0, err
+	}
+
+	setAutoUpdateFields(ex, fieldMap, t)
+	applyNormalizers(fieldMap, t)
+
+	params := append(argsForColumns(fieldMap.UpdateColumns, fieldMap, t), args...)
+
+	finalWhere := fieldMap.Driver.RenumberWhereClause(where, len(fieldMap.UpdateColumns))
+
+	result, err := debugged(ex).Exec(fieldMap.UpdateQuery+finalWhere, params...)
+	if err != nil {
+		return 0, err
+	}
+	return rowsAffected(result), fieldMap.Hooks.afterUpdate(t)
+}
+
+// rowsAffected returns result.RowsAffected(), or -1 when the driver doesn't
+// support it.
+func rowsAffected(result sql.Result) int64 {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return -1
+	}
+	return affected
+}
+
+// UpdateById updates the row of t identified by its own id. It refuses to run
+// while the id is still the zero value. Use UpdateByIdAffected to tell whether
+// a row was changed.
+func UpdateById[T any](ex Executor, t *T) (err error) {
+	defer wrapModelError[T]("UpdateById", &err)
+	_, err = UpdateByIdAffected(ex, t)
+	return err
+}
+
+// UpdateByIdAffected is UpdateById returning the number of affected rows, see
+// UpdateAffected. MySQL does not count rows whose values were already equal,
+// so 0 can also mean the row exists unchanged unless the DSN sets
+// clientFoundRows=true; check with SelectById before answering "not found".
+func UpdateByIdAffected[T any](ex Executor, t *T) (_ int64, err error) {
+	defer wrapModelError[T]("UpdateByIdAffected", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return 0, err
+	}
+	pos, ok := fieldMap.ColumnsMap["id"]
+	if !ok {
+		return 0, errors.New("model " + reflect.TypeFor[T]().Name() + " has no id column")
+	}
+	id := fieldMap.field(reflect.ValueOf(t).Elem(), pos)
+	if id.IsZero() {
+		return 0, errors.New("refusing to update " + reflect.TypeFor[T]().Name() + " with a zero id")
+	}
+	where := escapeIdentifier(fieldMap.Driver, "id") + " = " + fieldMap.Driver.Placeholder(1)
+	return UpdateAffected(ex, t, where, id.Interface())
+}
+
+// UpdateColumns is like Update but only writes the given columns, plus any
+// autoupdate columns. Including the id column is allowed, but rarely what you
+// want; for models registered with ExcludeIdFromUpdate it is left out of the
+// SET list.
+func UpdateColumns[T any](ex Executor, t *T, columns []string, where string, args ...any) (err error) {
+	defer wrapModelError[T]("UpdateColumns", &err)
+	if len(where) == 0 {
+		return errors.New("parameter 'where' was not present")
+	}
+	if len(columns) == 0 {
+		return errors.New("parameter 'columns' was empty")
+	}
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
 	if err != nil {
 		return err
 	}
 
-	if err := ValidateColumns[T](fieldMap.InsertColumns, fieldMap); err != nil {
+	if err := ValidateColumns[T](columns, fieldMap); err != nil {
 		return err
 	}
+	if slices.Contains(columns, "id") && !slices.Contains(fieldMap.UpdateColumns, "id") && slices.Contains(fieldMap.writableColumns, "id") {
+		// ExcludeIdFromUpdate: the key belongs in the WHERE clause only.
+		columns = slices.DeleteFunc(slices.Clone(columns), func(column string) bool {
+			return column == "id"
+		})
+		if len(columns) == 0 {
+			return errors.New("parameter 'columns' has no column to update besides id")
+		}
+	}
+	for _, column := range columns {
+		if !slices.Contains(fieldMap.UpdateColumns, column) {
+			return errors.New("column is read-only: " + column)
+		}
+	}
 
-	_, err = ex.Exec(fieldMap.InsertQuery, *GetPointersForColumns[T](fieldMap.InsertColumns, fieldMap, t)...)
-	return err
+	missing, err := fieldMap.MissingOptionalColumns(ex)
+	if err != nil {
+		return err
+	}
+	for _, column := range columns {
+		if slices.Contains(missing, column) {
+			return fmt.Errorf("cannot write optional column %s: it does not exist in table %s yet", column, fieldMap.TableName)
+		}
+	}
+
+	if err := fieldMap.Hooks.beforeUpdate(t); err != nil {
+		return err
+	}
+
+	setAutoUpdateFields(ex, fieldMap, t)
+	applyNormalizers(fieldMap, t)
+
+	if _, skip := ex.(skipAutoUpdateExecutor); !skip {
+		for _, pos := range fieldMap.AutoUpdateFields {
+			if column := fieldMap.ColumnKeys[pos]; !slices.Contains(columns, column) {
+				columns = append(slices.Clip(columns), column)
+			}
+		}
+	}
+
+	params := append(argsForColumns(columns, fieldMap, t), args...)
+
+	finalWhere := fieldMap.Driver.RenumberWhereClause(where, len(columns))
+
+	_, err = debugged(ex).Exec(partialUpdateQuery(fieldMap, columns)+finalWhere, params...)
+	if err != nil {
+		return err
+	}
+	return fieldMap.Hooks.afterUpdate(t)
 }
 
-func Update[T any](ex Executor, t *T, where string, args ...any) error {
+// UpdateNonZero is like UpdateColumns with the writable columns whose fields
+// are non-zero, for PATCH-style updates from a sparse struct. The id column is
+// never written. A field explicitly set to its zero value (0, "", false) is
+// skipped too; use pointer fields when zero is a meaningful update. Nothing is
+// executed when every field is zero.
+func UpdateNonZero[T any](ex Executor, t *T, where string, args ...any) (err error) {
+	defer wrapModelError[T]("UpdateNonZero", &err)
 	if len(where) == 0 {
 		return errors.New("parameter 'where' was not present")
 	}
-	tType := reflect.TypeOf(*t)
-	fieldMap, err := GetFieldMap(tType)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
 	if err != nil {
 		return err
 	}
 
-	if err := ValidateColumns[T](fieldMap.ColumnKeys, fieldMap); err != nil {
+	v := reflect.ValueOf(t).Elem()
+	columns := []string{}
+	for _, column := range fieldMap.UpdateColumns {
+		if column == "id" || slices.Contains(fieldMap.AutoUpdateFields, fieldMap.ColumnsMap[column]) {
+			continue
+		}
+		if !fieldMap.field(v, fieldMap.ColumnsMap[column]).IsZero() {
+			columns = append(columns, column)
+		}
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+	return UpdateColumns(ex, t, columns, where, args...)
+}
+
+// UpdateOmitEmpty is like Update, but leaves out the columns tagged
+// `lit:"...,omitempty"` whose fields hold their zero value (0, "", false, a
+// nil pointer). The UPDATE is generated per set of columns and cached.
+func UpdateOmitEmpty[T any](ex Executor, t *T, where string, args ...any) (err error) {
+	defer wrapModelError[T]("UpdateOmitEmpty", &err)
+	if len(where) == 0 {
+		return errors.New("parameter 'where' was not present")
+	}
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
 		return err
 	}
+	if len(fieldMap.OmitEmptyFields) == 0 {
+		return Update(ex, t, where, args...)
+	}
 
-	params := append(*GetPointersForColumns[T](fieldMap.ColumnKeys, fieldMap, t), args...)
+	v := reflect.ValueOf(t).Elem()
+	columns := []string{}
+	for _, column := range fieldMap.UpdateColumns {
+		pos := fieldMap.ColumnsMap[column]
+		if slices.Contains(fieldMap.OmitEmptyFields, pos) && fieldMap.field(v, pos).IsZero() {
+			continue
+		}
+		columns = append(columns, column)
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+	return UpdateColumns(ex, t, columns, where, args...)
+}
 
-	finalWhere := fieldMap.Driver.RenumberWhereClause(where, len(fieldMap.ColumnKeys))
+// UpdateMap updates the columns in changes, in sorted column order, on the
+// rows of T matching where. Autoupdate columns missing from changes are set to
+// the current time and normalized columns are normalized, as with Update. It
+// returns the number of affected rows, or -1 when the database driver cannot
+// report it.
+func UpdateMap[T any](ex Executor, changes map[string]any, where string, args ...any) (_ int64, err error) {
+	defer wrapModelError[T]("UpdateMap", &err)
+	if len(where) == 0 {
+		return 0, errors.New("parameter 'where' was not present")
+	}
+	if len(changes) == 0 {
+		return 0, errors.New("parameter 'changes' was empty")
+	}
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return 0, err
+	}
 
-	_, err = ex.Exec(fieldMap.UpdateQuery+finalWhere, params...)
-	return err
+	if err := ValidateColumns[T](slices.Collect(maps.Keys(changes)), fieldMap); err != nil {
+		return 0, err
+	}
+	for column := range changes {
+		if !slices.Contains(fieldMap.UpdateColumns, column) {
+			return 0, errors.New("column is read-only: " + column)
+		}
+	}
+
+	changes = maps.Clone(changes)
+	setAutoUpdateChanges(ex, fieldMap, changes)
+	normalizeChanges(fieldMap, changes)
+	columns := slices.Sorted(maps.Keys(changes))
+
+	params := make([]any, 0, len(columns)+len(args))
+	for _, column := range columns {
+		params = append(params, changes[column])
+	}
+	params = append(params, args...)
+
+	finalWhere := fieldMap.Driver.RenumberWhereClause(where, len(columns))
+
+	result, err := debugged(ex).Exec(partialUpdateQuery(fieldMap, columns)+finalWhere, params...)
+	if err != nil {
+		return 0, err
+	}
+	return rowsAffected(result), nil
+}
+
+// partialUpdateQuery returns the UPDATE ... WHERE prefix for columns, cached
+// per column set.
+func partialUpdateQuery(fieldMap *FieldMap, columns []string) string {
+	key := strings.Join(columns, ",")
+	if cached, ok := fieldMap.partialUpdateCache.Load(key); ok {
+		return cached.(string)
+	}
+	query := fieldMap.Driver.GenerateUpdateQuery(fieldMap.TableName, columns)
+	fieldMap.partialUpdateCache.Store(key, query)
+	return query
 }
 
 func Delete(ex Executor, query string, args ...any) error {
-	_, err := ex.Exec(query, args...)
+	_, err := DeleteAffected(ex, query, args...)
 	return err
 }
 
-func SelectMultipleNative[T any](ex Executor, mapLine func(*interface{ Scan(...any) error }, *T) error, query string, args ...any) ([]*T, error) {
-	rows, err := ex.Query(query, args...)
+// DeleteAffected is Delete returning the number of deleted rows, or -1 when
+// the database driver cannot report it.
+func DeleteAffected(ex Executor, query string, args ...any) (int64, error) {
+	result, err := debugged(ex).Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return rowsAffected(result), nil
+}
+
+// DeleteById deletes the row of T with the given id. It returns ErrNotFound
+// when no row was deleted.
+func DeleteById[T any](ex Executor, id any) (err error) {
+	defer wrapModelError[T]("DeleteById", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return err
+	}
+	if _, ok := fieldMap.ColumnsMap["id"]; !ok {
+		return errors.New("model " + reflect.TypeFor[T]().Name() + " has no id column")
+	}
+
+	affected, err := deleteById(ex, fieldMap, id)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// DeleteModel deletes the row of t by its id and returns the number of rows
+// deleted, or -1 when the database driver cannot report it. It refuses to run
+// while the id is still the zero value.
+func DeleteModel[T any](ex Executor, t *T) (_ int64, err error) {
+	defer wrapModelError[T]("DeleteModel", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return 0, err
+	}
+	pos, ok := fieldMap.ColumnsMap["id"]
+	if !ok {
+		return 0, errors.New("model " + reflect.TypeFor[T]().Name() + " has no id column")
+	}
+	id := fieldMap.field(reflect.ValueOf(t).Elem(), pos)
+	if id.IsZero() {
+		return 0, errors.New("refusing to delete " + reflect.TypeFor[T]().Name() + " with a zero id")
+	}
+	return deleteById(ex, fieldMap, id.Interface())
+}
+
+// byIdsChunkSize bounds the placeholders of one ... WHERE id IN statement,
+// staying below SQLite's historical limit of 999 bound parameters.
+const byIdsChunkSize = 500
+
+// DeleteByIDs deletes the rows of T with the given integer ids, see
+// DeleteByIDsTyped.
+func DeleteByIDs[T any](ex Executor, ids []int) (err error) {
+	defer wrapModelError[T]("DeleteByIDs", &err)
+	return DeleteByIDsTyped[T](ex, ids)
+}
+
+// DeleteByIDsTyped deletes the rows of T with the given ids using
+// DELETE ... WHERE id IN (...), binding the ids as arguments. Large slices are
+// split into several statements, run inside a transaction if they must be
+// atomic. An empty slice does nothing.
+func DeleteByIDsTyped[T any, ID int | int64 | string](ex Executor, ids []ID) (err error) {
+	defer wrapModelError[T]("DeleteByIDsTyped", &err)
+	if len(ids) == 0 {
+		return nil
+	}
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return err
+	}
+	if _, ok := fieldMap.ColumnsMap["id"]; !ok {
+		return errors.New("model " + reflect.TypeFor[T]().Name() + " has no id column")
+	}
+
+	prefix := "DELETE FROM " + escapeTable(fieldMap.Driver, fieldMap.TableName) +
+		" WHERE " + escapeIdentifier(fieldMap.Driver, "id") + " IN ("
+	for chunk := range slices.Chunk(ids, byIdsChunkSize) {
+		args := make([]any, len(chunk))
+		for i, id := range chunk {
+			if err := fieldMap.Hooks.beforeDelete(id); err != nil {
+				return err
+			}
+			args[i] = id
+		}
+		query := prefix + fieldMap.Driver.JoinStringForIn(0, len(chunk)) + ")"
+		if _, err := debugged(ex).Exec(query, args...); err != nil {
+			return err
+		}
+		for _, id := range chunk {
+			if err := fieldMap.Hooks.afterDelete(id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func deleteById(ex Executor, fieldMap *FieldMap, id any) (int64, error) {
+	if err := fieldMap.Hooks.beforeDelete(id); err != nil {
+		return 0, err
+	}
+	query := "DELETE FROM " + escapeTable(fieldMap.Driver, fieldMap.TableName) +
+		" WHERE " + escapeIdentifier(fieldMap.Driver, "id") + " = " + fieldMap.Driver.Placeholder(1)
+	result, err := debugged(ex).Exec(query, id)
+	if err != nil {
+		return 0, err
+	}
+	return rowsAffected(result), fieldMap.Hooks.afterDelete(id)
+}
+
+// DeleteChunked deletes the rows of T matching where in batches of chunkSize,
+// sleeping pause between batches, until a batch deletes nothing. Each batch is
+// reported to the ChunkHooks of a NewHookedExecutor, e.g. LoggingHook, and to
+// the debug logger. It returns the total number of deleted rows, also when ctx
+// is cancelled midway. When the database driver cannot report affected rows,
+// it stops after the first batch and returns -1.
+func DeleteChunked[T any](ctx context.Context, ex Executor, where string, chunkSize int, pause time.Duration, args ...any) (_ int64, err error) {
+	defer wrapModelError[T]("DeleteChunked", &err)
+	if len(where) == 0 {
+		return 0, errors.New("parameter 'where' was not present")
+	}
+	if chunkSize <= 0 {
+		return 0, errors.New("parameter 'chunkSize' must be positive")
+	}
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return 0, err
+	}
+
+	query := chunkedDeleteQuery(fieldMap, where, chunkSize)
+
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		result, err := debugged(ex).Exec(query, args...)
+		if err != nil {
+			return total, err
+		}
+		affected := rowsAffected(result)
+		if affected < 0 {
+			// Without a count there is no telling when the rows run out.
+			reportChunk(ctx, ex, "DeleteChunked", fieldMap.TableName, -1, -1)
+			return -1, nil
+		}
+		total += affected
+		reportChunk(ctx, ex, "DeleteChunked", fieldMap.TableName, affected, total)
+		if affected == 0 {
+			return total, nil
+		}
+
+		if pause > 0 {
+			timer := time.NewTimer(pause)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return total, ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// chunkedDeleteQuery builds a bounded DELETE. MySQL supports DELETE ... LIMIT
+// but not LIMIT inside an IN subquery, everything else gets the subquery form,
+// bounded with FETCH FIRST where the driver doesn't page with LIMIT.
+func chunkedDeleteQuery(fieldMap *FieldMap, where string, chunkSize int) string {
+	table := escapeTable(fieldMap.Driver, fieldMap.TableName)
+	limit := strconv.Itoa(chunkSize)
+
+	if _, ok := fieldMap.Driver.(*mysqlDriver); ok {
+		return "DELETE FROM " + table + " WHERE " + where + " LIMIT " + limit
+	}
+	bound := " LIMIT " + limit
+	if _, ok := fieldMap.Driver.(pager); ok {
+		bound = " FETCH FIRST " + limit + " ROWS ONLY"
+	}
+	id := escapeIdentifier(fieldMap.Driver, "id")
+	return "DELETE FROM " + table + " WHERE " + id + " IN (SELECT " + id + " FROM " + table + " WHERE " + where + bound + ")"
+}
+
+func SelectMultipleNative[T any](ex Executor, mapLine func(*interface{ Scan(...any) error }, *T) error, query string, args ...any) (_ []*T, err error) {
+	defer wrapModelError[T]("SelectMultipleNative", &err)
+	rows, err := debugged(ex).Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -182,7 +1001,7 @@ func SelectMultipleNative[T any](ex Executor, mapLine func(*interface{ Scan(...a
 }
 
 func InsertNative(ex Executor, query string, args ...any) (int, error) {
-	result, err := ex.Exec(query, args...)
+	result, err := debugged(ex).Exec(query, args...)
 	if err != nil {
 		return 0, err
 	}
@@ -196,6 +1015,6 @@ func InsertNative(ex Executor, query string, args ...any) (int, error) {
 }
 
 func UpdateNative(ex Executor, query string, args ...any) error {
-	_, err := ex.Exec(query, args...)
+	_, err := debugged(ex).Exec(query, args...)
 	return err
 }
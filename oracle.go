@@ -0,0 +1,291 @@
+//go:build oracle
+
+package lit
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type oracleDriver struct{}
+
+// Oracle is the driver for Oracle Database, e.g. through github.com/sijms/go-ora/v2.
+// It is only compiled with the oracle build tag.
+var Oracle Driver = &oracleDriver{}
+
+func init() {
+	driversByName["oracle"] = Oracle
+	driversByPackage["github.com/sijms/go-ora/v2"] = "oracle"
+}
+
+func (d *oracleDriver) Name() string { return "Oracle" }
+
+func (d *oracleDriver) String() string { return d.Name() }
+
+// GenerateInsertQuery binds the generated integer id to the :out_id output
+// parameter InsertAndGetId passes. Other ids are written by the caller, so
+// their query has no RETURNING clause.
+func (d *oracleDriver) GenerateInsertQuery(tableName string, columnKeys []string, hasIntId bool) (string, []string) {
+	var insertQuery strings.Builder
+
+	insertQuery.WriteString("INSERT INTO ")
+	insertQuery.WriteString(escapeTableName(tableName, oracleEscapeReserved))
+	insertQuery.WriteString(" (")
+
+	totalKeys := len(columnKeys)
+	for i, k := range columnKeys {
+		insertQuery.WriteString(oracleEscapeReserved(k))
+		if i != totalKeys-1 {
+			insertQuery.WriteString(",")
+		}
+	}
+
+	insertQuery.WriteString(") VALUES (")
+
+	counter := 1
+	insertColumns := []string{}
+	for i, k := range columnKeys {
+		if hasIntId && k == "id" {
+			insertQuery.WriteString("DEFAULT")
+		} else {
+			insertColumns = append(insertColumns, k)
+			insertQuery.WriteString(":" + strconv.Itoa(counter))
+			counter++
+		}
+		if i != totalKeys-1 {
+			insertQuery.WriteString(",")
+		}
+	}
+	insertQuery.WriteString(")")
+	if hasIntId {
+		insertQuery.WriteString(" RETURNING id INTO :out_id")
+	}
+
+	return insertQuery.String(), insertColumns
+}
+
+func (d *oracleDriver) GenerateUpdateQuery(tableName string, columnKeys []string) string {
+	var updateQuery strings.Builder
+	updateQuery.WriteString("UPDATE ")
+	updateQuery.WriteString(escapeTableName(tableName, oracleEscapeReserved))
+	updateQuery.WriteString(" SET ")
+
+	totalKeys := len(columnKeys)
+	for i, k := range columnKeys {
+		updateQuery.WriteString(oracleEscapeReserved(k))
+		updateQuery.WriteString(" = :" + strconv.Itoa(i+1))
+		if i != totalKeys-1 {
+			updateQuery.WriteString(",")
+		}
+	}
+
+	updateQuery.WriteString(" WHERE ")
+
+	return updateQuery.String()
+}
+
+// InsertAndGetId reads the id through the :out_id output parameter, since
+// Oracle can't return rows from an INSERT.
+func (d *oracleDriver) InsertAndGetId(ex Executor, query string, args ...any) (int, error) {
+	var id int64
+	args = append(args, sql.Named("out_id", sql.Out{Dest: &id}))
+	if _, err := debugged(ex).Exec(query, args...); err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+func (d *oracleDriver) Placeholder(argIndex int) string {
+	return ":" + strconv.Itoa(argIndex)
+}
+
+func (d *oracleDriver) SupportsBackslashEscape() bool { return false }
+
+func (d *oracleDriver) RenumberWhereClause(where string, offset int) string {
+	return oracleRenumberPlaceholders(where, offset)
+}
+
+func (d *oracleDriver) JoinStringForIn(offset int, count int) string {
+	var sb strings.Builder
+	for i := 0; i < count; i++ {
+		sb.WriteString(":" + strconv.Itoa(i+1+offset))
+		if i < count-1 {
+			sb.WriteString(",")
+		}
+	}
+	return sb.String()
+}
+
+// ForShareClause is empty: Oracle only locks rows with FOR UPDATE.
+func (d *oracleDriver) ForShareClause() string { return "" }
+
+func (d *oracleDriver) NormalizeTime(t time.Time) time.Time { return t.Round(0).UTC() }
+
+func (d *oracleDriver) escapeReserved(name string) string { return oracleEscapeReserved(name) }
+
+// pagingClause uses the OFFSET/FETCH row limiting clause, Oracle has no
+// LIMIT. OFFSET comes first, so its argument is bound first.
+func (d *oracleDriver) pagingClause(args []any, limit int, offset int) (string, []any) {
+	clause := ""
+	if offset >= 0 {
+		args = append(args, offset)
+		clause += " OFFSET " + d.Placeholder(len(args)) + " ROWS"
+	}
+	if limit >= 0 {
+		args = append(args, limit)
+		clause += " FETCH NEXT " + d.Placeholder(len(args)) + " ROWS ONLY"
+	}
+	return clause, args
+}
+
+// oracleRenumberPlaceholders renumbers :N placeholders like
+// pgRenumberPlaceholders does for $N. Named binds such as :out_id are left
+// alone.
+func oracleRenumberPlaceholders(where string, offset int) string {
+	if !strings.Contains(where, ":") {
+		return where
+	}
+
+	var newWhere strings.Builder
+	for i := 0; i < len(where); i++ {
+		c := where[i]
+		newWhere.WriteByte(c)
+		if c != ':' || i+1 >= len(where) || where[i+1] < '0' || where[i+1] > '9' {
+			continue
+		}
+		for i+1 < len(where) && where[i+1] >= '0' && where[i+1] <= '9' {
+			i++
+		}
+		offset++
+		newWhere.WriteString(strconv.Itoa(offset))
+	}
+
+	return newWhere.String()
+}
+
+func oracleEscapeReserved(tableOrColumn string) string {
+	escaped := strings.ReplaceAll(tableOrColumn, `"`, `""`)
+
+	if _, exists := oracleReservedKeywords[strings.ToUpper(tableOrColumn)]; exists {
+		return `"` + escaped + `"`
+	}
+	return tableOrColumn
+}
+
+// ensure oracleDriver implements Driver at compile time
+var _ Driver = (*oracleDriver)(nil)
+var _ fmt.Stringer = (*oracleDriver)(nil)
+
+var oracleReservedKeywords = map[string]struct{}{
+	"ACCESS":     {},
+	"ADD":        {},
+	"ALL":        {},
+	"ALTER":      {},
+	"AND":        {},
+	"ANY":        {},
+	"AS":         {},
+	"ASC":        {},
+	"AUDIT":      {},
+	"BETWEEN":    {},
+	"BY":         {},
+	"CHAR":       {},
+	"CHECK":      {},
+	"CLUSTER":    {},
+	"COLUMN":     {},
+	"COMMENT":    {},
+	"COMPRESS":   {},
+	"CONNECT":    {},
+	"CREATE":     {},
+	"CURRENT":    {},
+	"DATE":       {},
+	"DECIMAL":    {},
+	"DEFAULT":    {},
+	"DELETE":     {},
+	"DESC":       {},
+	"DISTINCT":   {},
+	"DROP":       {},
+	"ELSE":       {},
+	"EXCLUSIVE":  {},
+	"EXISTS":     {},
+	"FILE":       {},
+	"FLOAT":      {},
+	"FOR":        {},
+	"FROM":       {},
+	"GRANT":      {},
+	"GROUP":      {},
+	"HAVING":     {},
+	"IDENTIFIED": {},
+	"IMMEDIATE":  {},
+	"IN":         {},
+	"INCREMENT":  {},
+	"INDEX":      {},
+	"INITIAL":    {},
+	"INSERT":     {},
+	"INTEGER":    {},
+	"INTERSECT":  {},
+	"INTO":       {},
+	"IS":         {},
+	"LEVEL":      {},
+	"LIKE":       {},
+	"LOCK":       {},
+	"LONG":       {},
+	"MAXEXTENTS": {},
+	"MINUS":      {},
+	"MLSLABEL":   {},
+	"MODE":       {},
+	"MODIFY":     {},
+	"NOAUDIT":    {},
+	"NOCOMPRESS": {},
+	"NOT":        {},
+	"NOWAIT":     {},
+	"NULL":       {},
+	"NUMBER":     {},
+	"OF":         {},
+	"OFFLINE":    {},
+	"ON":         {},
+	"ONLINE":     {},
+	"OPTION":     {},
+	"OR":         {},
+	"ORDER":      {},
+	"PCTFREE":    {},
+	"PRIOR":      {},
+	"PUBLIC":     {},
+	"RAW":        {},
+	"RENAME":     {},
+	"RESOURCE":   {},
+	"REVOKE":     {},
+	"ROW":        {},
+	"ROWID":      {},
+	"ROWNUM":     {},
+	"ROWS":       {},
+	"SELECT":     {},
+	"SESSION":    {},
+	"SET":        {},
+	"SHARE":      {},
+	"SIZE":       {},
+	"SMALLINT":   {},
+	"START":      {},
+	"SUCCESSFUL": {},
+	"SYNONYM":    {},
+	"SYSDATE":    {},
+	"TABLE":      {},
+	"THEN":       {},
+	"TO":         {},
+	"TRIGGER":    {},
+	"UID":        {},
+	"UNION":      {},
+	"UNIQUE":     {},
+	"UPDATE":     {},
+	"USER":       {},
+	"VALIDATE":   {},
+	"VALUES":     {},
+	"VARCHAR":    {},
+	"VARCHAR2":   {},
+	"VIEW":       {},
+	"WHENEVER":   {},
+	"WHERE":      {},
+	"WITH":       {},
+}
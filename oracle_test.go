@@ -0,0 +1,92 @@
+//go:build oracle
+
+package lit
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOracle_GenerateInsertQuery(t *testing.T) {
+	query, columns := Oracle.GenerateInsertQuery("users", []string{"id", "first_name", "level"}, true)
+	assert.Equal(t, `INSERT INTO users (id,first_name,"level") VALUES (DEFAULT,:1,:2) RETURNING id INTO :out_id`, query)
+	assert.Equal(t, []string{"first_name", "level"}, columns)
+
+	query, columns = Oracle.GenerateInsertQuery("products", []string{"id", "title"}, false)
+	assert.Equal(t, "INSERT INTO products (id,title) VALUES (:1,:2)", query)
+	assert.Equal(t, []string{"id", "title"}, columns)
+}
+
+func TestOracle_GenerateUpdateQuery(t *testing.T) {
+	query := Oracle.GenerateUpdateQuery("users", []string{"id", "first_name", "comment"})
+	assert.Equal(t, `UPDATE users SET id = :1,first_name = :2,"comment" = :3 WHERE `, query)
+}
+
+func TestOracle_Placeholders(t *testing.T) {
+	assert.Equal(t, ":3", Oracle.Placeholder(3))
+	assert.Equal(t, ":4,:5,:6", Oracle.JoinStringForIn(3, 3))
+	assert.Equal(t, "id = :4 AND email = :5", Oracle.RenumberWhereClause("id = :1 AND email = :2", 3))
+	assert.Equal(t, "id = :2 AND code = :name", Oracle.RenumberWhereClause("id = :1 AND code = :name", 1))
+	assert.Equal(t, `"user"`, escapeIdentifier(Oracle, "user"))
+}
+
+// outConverter lets sqlmock accept the sql.Out argument InsertAndGetId binds.
+type outConverter struct{}
+
+func (outConverter) ConvertValue(v any) (driver.Value, error) {
+	if _, ok := v.(sql.Out); ok {
+		return v, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
+func TestOracle_InsertAndGetId(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual), sqlmock.ValueConverterOption(outConverter{}))
+	require.NoError(t, err)
+	defer db.Close()
+
+	query := "INSERT INTO users (id,first_name) VALUES (DEFAULT,:1) RETURNING id INTO :out_id"
+	mock.ExpectExec(query).
+		WithArgs("John", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	_, err = Oracle.InsertAndGetId(db, query, "John")
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOracle_DriverFromDSN(t *testing.T) {
+	driver, err := DriverFromDSN("oracle")
+	require.NoError(t, err)
+	assert.Equal(t, Oracle, driver)
+}
+
+func TestOracle_PagingClause(t *testing.T) {
+	clause, args := pagingClause(Oracle, []any{"a"}, 10, 20)
+	assert.Equal(t, " OFFSET :2 ROWS FETCH NEXT :3 ROWS ONLY", clause)
+	assert.Equal(t, []any{"a", 20, 10}, args)
+
+	clause, args = pagingClause(Oracle, nil, 5, -1)
+	assert.Equal(t, " FETCH NEXT :1 ROWS ONLY", clause)
+	assert.Equal(t, []any{5}, args)
+
+	clause, args = pagingClause(Oracle, nil, -1, 5)
+	assert.Equal(t, " OFFSET :1 ROWS", clause)
+	assert.Equal(t, []any{5}, args)
+
+	clause, args = pagingClause(PostgreSQL, nil, 10, 20)
+	assert.Equal(t, " LIMIT $1 OFFSET $2", clause)
+	assert.Equal(t, []any{10, 20}, args)
+}
+
+func TestOracle_ChunkedDeleteQuery(t *testing.T) {
+	fieldMap := &FieldMap{TableName: "users", Driver: Oracle}
+	assert.Equal(t,
+		"DELETE FROM users WHERE id IN (SELECT id FROM users WHERE active = :1 FETCH FIRST 100 ROWS ONLY)",
+		chunkedDeleteQuery(fieldMap, "active = :1", 100))
+}
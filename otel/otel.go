@@ -0,0 +1,115 @@
+// Package otel wraps a lit.Executor so every statement is recorded as an
+// OpenTelemetry client span named after its operation and table, carrying the
+// db.system, db.statement, db.operation and db.sql.table attributes.
+package otel
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/tracewayapp/lit/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type config struct {
+	system string
+}
+
+// Option configures NewInstrumentedExecutor.
+type Option func(*config)
+
+// WithDriver sets the db.system attribute from a lit driver.
+func WithDriver(driver lit.Driver) Option {
+	return func(c *config) {
+		c.system = dbSystem(driver)
+	}
+}
+
+// InstrumentedExecutor starts a span around every Exec, Query and QueryRow.
+type InstrumentedExecutor struct {
+	ex     lit.Executor
+	tracer trace.Tracer
+	config config
+}
+
+// NewInstrumentedExecutor returns ex wrapped so each statement is traced with
+// tracer. Span names follow the semantic conventions, e.g. "SELECT users".
+func NewInstrumentedExecutor(ex lit.Executor, tracer trace.Tracer, opts ...Option) lit.Executor {
+	c := config{}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return &InstrumentedExecutor{ex: ex, tracer: tracer, config: c}
+}
+
+func (e *InstrumentedExecutor) Exec(query string, args ...any) (sql.Result, error) {
+	span := e.start(query)
+	result, err := e.ex.Exec(query, args...)
+	end(span, err)
+	return result, err
+}
+
+func (e *InstrumentedExecutor) Query(query string, args ...any) (*sql.Rows, error) {
+	span := e.start(query)
+	rows, err := e.ex.Query(query, args...)
+	end(span, err)
+	return rows, err
+}
+
+func (e *InstrumentedExecutor) QueryRow(query string, args ...any) *sql.Row {
+	span := e.start(query)
+	row := e.ex.QueryRow(query, args...)
+	end(span, row.Err())
+	return row
+}
+
+func (e *InstrumentedExecutor) start(query string) trace.Span {
+	operation, table := lit.DescribeQuery(query)
+	name := operation
+	if table != "" {
+		name += " " + table
+	}
+
+	attrs := []attribute.KeyValue{attribute.String("db.statement", query)}
+	if e.config.system != "" {
+		attrs = append(attrs, attribute.String("db.system", e.config.system))
+	}
+	if table != "" {
+		attrs = append(attrs, attribute.String("db.sql.table", table))
+	}
+	if operation != "" {
+		attrs = append(attrs, attribute.String("db.operation", operation))
+	}
+
+	// lit.Executor carries no context, so spans start from the background context.
+	_, span := e.tracer.Start(context.Background(), name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	return span
+}
+
+func end(span trace.Span, err error) {
+	if err != nil && err != sql.ErrNoRows {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func dbSystem(driver lit.Driver) string {
+	switch driver {
+	case lit.PostgreSQL:
+		return "postgresql"
+	case lit.MySQL:
+		return "mysql"
+	case lit.SQLite:
+		return "sqlite"
+	case lit.CockroachDB:
+		return "cockroachdb"
+	}
+	return strings.ToLower(driver.Name())
+}
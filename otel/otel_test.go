@@ -0,0 +1,25 @@
+package otel
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tracewayapp/lit/v2"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestInstrumentedExecutor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("DELETE FROM users").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ex := NewInstrumentedExecutor(db, noop.NewTracerProvider().Tracer("test"), WithDriver(lit.PostgreSQL))
+	_, err = ex.Exec("DELETE FROM users WHERE id = $1", 1)
+	require.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
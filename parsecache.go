@@ -0,0 +1,61 @@
+package lit
+
+import "sync"
+
+// defaultNamedQueryCacheSize is the number of parsed named queries kept by
+// default, see SetNamedQueryCacheSize.
+const defaultNamedQueryCacheSize = 1000
+
+type namedQueryKey struct {
+	driver Driver
+	query  string
+	syntax ParamSyntax
+}
+
+// queryCache holds parsed named queries. Only the query text is cached, never
+// parameter values. When it is full it is emptied and refilled, which keeps
+// the hot queries of a service cached without tracking recency.
+type queryCache struct {
+	mu      sync.RWMutex
+	size    int
+	entries map[namedQueryKey]*namedQuery
+}
+
+var namedQueryCache = &queryCache{
+	size:    defaultNamedQueryCacheSize,
+	entries: map[namedQueryKey]*namedQuery{},
+}
+
+func (c *queryCache) get(driver Driver, query string, syntax ParamSyntax) *namedQuery {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.entries[namedQueryKey{driver, query, syntax}]
+}
+
+func (c *queryCache) put(driver Driver, query string, syntax ParamSyntax, parsed *namedQuery) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.size <= 0 {
+		return
+	}
+	if len(c.entries) >= c.size {
+		clear(c.entries)
+	}
+	c.entries[namedQueryKey{driver, query, syntax}] = parsed
+}
+
+// SetNamedQueryCacheSize sets how many parsed named queries are kept, 1000 by
+// default. Zero disables the cache.
+func SetNamedQueryCacheSize(size int) {
+	namedQueryCache.mu.Lock()
+	defer namedQueryCache.mu.Unlock()
+	namedQueryCache.size = size
+	clear(namedQueryCache.entries)
+}
+
+// ClearNamedQueryCache drops every cached named query.
+func ClearNamedQueryCache() {
+	namedQueryCache.mu.Lock()
+	defer namedQueryCache.mu.Unlock()
+	clear(namedQueryCache.entries)
+}
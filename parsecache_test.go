@@ -0,0 +1,103 @@
+package lit
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamedQueryCache(t *testing.T) {
+	ClearNamedQueryCache()
+	defer ClearNamedQueryCache()
+
+	query := "SELECT * FROM users WHERE id = :id AND email = :email"
+	q, args, err := ParseNamedQuery(PostgreSQL, query, P{"id": 1, "email": "a@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE id = $1 AND email = $2", q)
+	assert.Equal(t, []any{1, "a@example.com"}, args)
+
+	cached := namedQueryCache.get(PostgreSQL, query, ColonSyntax)
+	require.NotNil(t, cached)
+	assert.Equal(t, []string{"id", "email"}, cached.names)
+
+	// The second call is served from the cache with the new values.
+	q, args, err = ParseNamedQuery(PostgreSQL, query, P{"id": 2, "email": "b@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE id = $1 AND email = $2", q)
+	assert.Equal(t, []any{2, "b@example.com"}, args)
+
+	// Entries are per driver.
+	q, _, err = ParseNamedQuery(MySQL, query, P{"id": 2, "email": "b@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE id = ? AND email = ?", q)
+
+	_, _, err = ParseNamedQuery(PostgreSQL, query, P{"id": 2})
+	assert.EqualError(t, err, "missing parameter: email")
+}
+
+func TestSetNamedQueryCacheSize(t *testing.T) {
+	defer SetNamedQueryCacheSize(defaultNamedQueryCacheSize)
+
+	SetNamedQueryCacheSize(2)
+	for i := range 3 {
+		_, _, err := ParseNamedQuery(PostgreSQL, "SELECT "+strconv.Itoa(i)+" WHERE id = :id", P{"id": i})
+		require.NoError(t, err)
+	}
+	assert.Len(t, namedQueryCache.entries, 1)
+
+	SetNamedQueryCacheSize(0)
+	_, _, err := ParseNamedQuery(PostgreSQL, "SELECT 1 WHERE id = :id", P{"id": 1})
+	require.NoError(t, err)
+	assert.Empty(t, namedQueryCache.entries)
+}
+
+func TestNamedQueryCache_Concurrent(t *testing.T) {
+	defer ClearNamedQueryCache()
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				q, args, err := ParseNamedQuery(PostgreSQL, "SELECT * FROM t WHERE a = :a AND b = :b", P{"a": i, "b": j})
+				assert.NoError(t, err)
+				assert.Equal(t, "SELECT * FROM t WHERE a = $1 AND b = $2", q)
+				assert.Equal(t, []any{i, j}, args)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func benchmarkSelectNamed(b *testing.B, cacheSize int) {
+	SetNamedQueryCacheSize(cacheSize)
+	defer SetNamedQueryCacheSize(defaultNamedQueryCacheSize)
+
+	RegisterModel[TestUser](PostgreSQL)
+	db, mock, err := sqlmock.New()
+	require.NoError(b, err)
+	defer db.Close()
+
+	query := "SELECT id, first_name, last_name, email FROM test_users " +
+		"WHERE last_name = :last_name AND email LIKE :domain AND id > :after " +
+		"ORDER BY id LIMIT :limit"
+	params := P{"last_name": "Doe", "domain": "%@example.com", "after": 10, "limit": 20}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		b.StopTimer()
+		mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "email"}).AddRow(11, "John", "Doe", "john@example.com"))
+		b.StartTimer()
+		if _, err := SelectNamed[TestUser](db, query, params); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSelectNamed(b *testing.B)         { benchmarkSelectNamed(b, defaultNamedQueryCacheSize) }
+func BenchmarkSelectNamed_NoCache(b *testing.B) { benchmarkSelectNamed(b, 0) }
@@ -1,110 +1,168 @@
 package lit
 
 import (
+	"database/sql"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"unicode"
 )
 
+// SliceParam marks a named parameter as a list for an IN clause. ParseNamedQuery
+// expands it to a parenthesized placeholder per element, so "id IN :ids" with
+// lit.P{"ids": lit.SliceParam[int]{1, 2}} becomes "id IN ($1,$2)". An empty
+// list becomes "(NULL)", which matches no rows. Plain slices are bound as a
+// single value, e.g. for PostgreSQL arrays.
+type SliceParam[T any] []T
+
+func (s SliceParam[T]) inValues() []any {
+	values := make([]any, len(s))
+	for i, v := range s {
+		values[i] = v
+	}
+	return values
+}
+
+type inList interface {
+	inValues() []any
+}
+
+// ParseNamedQuery rewrites the :name parameters of query to driver's
+// placeholders and returns the matching args from params. The rewritten query
+// is cached per driver and query, see SetNamedQueryCacheSize.
 func ParseNamedQuery(driver Driver, query string, params map[string]any) (string, []any, error) {
+	return parseNamed(driver, query, params)
+}
+
+// ParseNamedQueryStrict is ParseNamedQuery that also fails with
+// ErrUnusedParams when params has keys the query never references, e.g. a
+// misspelled name.
+func ParseNamedQueryStrict(driver Driver, query string, params map[string]any) (string, []any, error) {
+	return parseNamed(driver, query, params, WithStrictParams())
+}
+
+// ParseNamedQueryWithSyntax is ParseNamedQuery for queries whose parameters
+// are written in syntax, e.g. @name with AtSyntax.
+func ParseNamedQueryWithSyntax(driver Driver, query string, params map[string]any, syntax ParamSyntax) (string, []any, error) {
+	return parseNamed(driver, query, params, WithParamSyntax(syntax))
+}
+
+// ParamSyntax is how named parameters are written in a query.
+type ParamSyntax int
+
+const (
+	// ColonSyntax is :name, the default.
+	ColonSyntax ParamSyntax = iota
+	// AtSyntax is @name, as in SQL Server and sqlc queries.
+	AtSyntax
+)
+
+func (s ParamSyntax) marker() rune {
+	if s == AtSyntax {
+		return '@'
+	}
+	return ':'
+}
+
+func (s ParamSyntax) other() rune {
+	if s == AtSyntax {
+		return ':'
+	}
+	return '@'
+}
+
+// NamedOption configures how the *Named functions parse their query.
+type NamedOption func(*namedOptions)
+
+type namedOptions struct {
+	syntax ParamSyntax
+	strict bool
+}
+
+// WithParamSyntax sets the syntax the query's parameters are written in.
+func WithParamSyntax(syntax ParamSyntax) NamedOption {
+	return func(o *namedOptions) {
+		o.syntax = syntax
+	}
+}
+
+// WithStrictParams makes a single call fail with ErrUnusedParams like
+// ParseNamedQueryStrict, regardless of SetStrictNamedParams.
+func WithStrictParams() NamedOption {
+	return func(o *namedOptions) {
+		o.strict = true
+	}
+}
+
+func parseNamed(driver Driver, query string, params map[string]any, opts ...NamedOption) (string, []any, error) {
 	if driver == nil {
 		return "", nil, fmt.Errorf("driver is nil")
 	}
+	var o namedOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return compileNamedQuery(driver, query, o.syntax).bind(driver, params, o.strict || strictNamedParams.Load())
+}
+
+var strictNamedParams atomic.Bool
 
+// SetStrictNamedParams makes ParseNamedQuery, and every *Named function, behave
+// like ParseNamedQueryStrict. It is off by default.
+func SetStrictNamedParams(strict bool) {
+	strictNamedParams.Store(strict)
+}
+
+// namedQuery is a parsed named query: the text between the parameters, the
+// parameter names in order, and the text with driver placeholders for the
+// common case where no SliceParam needs expanding. foreign holds the
+// parameters written in the other syntax, e.g. @id in a :name query, which are
+// left in the text, and positional the first positional placeholder.
+type namedQuery struct {
+	pieces     []string
+	names      []string
+	foreign    []string
+	positional string
+	sql        string
+}
+
+func compileNamedQuery(driver Driver, query string, syntax ParamSyntax) *namedQuery {
+	if !reflect.TypeOf(driver).Comparable() {
+		// Can't be a map key; parse every time.
+		return parseNamedQuery(driver, query, syntax)
+	}
+	if cached := namedQueryCache.get(driver, query, syntax); cached != nil {
+		return cached
+	}
+	parsed := parseNamedQuery(driver, query, syntax)
+	namedQueryCache.put(driver, query, syntax, parsed)
+	return parsed
+}
+
+func parseNamedQuery(driver Driver, query string, syntax ParamSyntax) *namedQuery {
 	runes := []rune(query)
 	var out strings.Builder
-	var args []any
-	argIndex := 0
+	parsed := &namedQuery{}
+	lexer := newSQLLexer(driver)
 
 	for i := 0; i < len(runes); i++ {
 		r := runes[i]
 
-		// Single-quoted string literal: copy verbatim
-		if r == '\'' {
-			out.WriteRune(r)
-			i++
-			for i < len(runes) {
-				// MySQL backslash escape: skip the next character
-				if driver.SupportsBackslashEscape() && runes[i] == '\\' && i+1 < len(runes) {
-					out.WriteRune(runes[i])
-					i++
-					out.WriteRune(runes[i])
-					i++
-					continue
-				}
-				out.WriteRune(runes[i])
-				if runes[i] == '\'' {
-					// Check for escaped quote ''
-					if i+1 < len(runes) && runes[i+1] == '\'' {
-						i++
-						out.WriteRune(runes[i])
-						i++
-						continue
-					}
-					break
-				}
-				i++
-			}
-			continue
-		}
-
-		// Double-quoted string/identifier: copy verbatim
-		if r == '"' {
-			out.WriteRune(r)
-			i++
-			for i < len(runes) {
-				// MySQL backslash escape: skip the next character
-				if driver.SupportsBackslashEscape() && runes[i] == '\\' && i+1 < len(runes) {
-					out.WriteRune(runes[i])
-					i++
-					out.WriteRune(runes[i])
-					i++
-					continue
-				}
-				out.WriteRune(runes[i])
-				if runes[i] == '"' {
-					// Check for escaped quote ""
-					if i+1 < len(runes) && runes[i+1] == '"' {
-						i++
-						out.WriteRune(runes[i])
-						i++
-						continue
-					}
-					break
-				}
-				i++
-			}
-			continue
-		}
-
-		// Backtick identifier: copy verbatim
-		if r == '`' {
-			out.WriteRune(r)
-			i++
-			for i < len(runes) {
-				out.WriteRune(runes[i])
-				if runes[i] == '`' {
-					// Check for escaped backtick ``
-					if i+1 < len(runes) && runes[i+1] == '`' {
-						i++
-						out.WriteRune(runes[i])
-						i++
-						continue
-					}
-					break
-				}
-				i++
-			}
+		// String literal, quoted identifier or comment: copy verbatim
+		if end := lexer.skip(runes, i); end != -1 {
+			out.WriteString(string(runes[i:end]))
+			i = end - 1
 			continue
 		}
 
-		// Colon handling
-		if r == ':' {
-			// Double colon :: (PG type cast) — emit literally
-			if i+1 < len(runes) && runes[i+1] == ':' {
-				out.WriteRune(':')
-				out.WriteRune(':')
+		// Parameter marker (: or @) handling
+		if r == syntax.marker() || r == syntax.other() {
+			// Doubled marker, e.g. :: (PG type cast) or @@ — emit literally
+			if i+1 < len(runes) && runes[i+1] == r {
+				out.WriteRune(r)
+				out.WriteRune(r)
 				i++
 				continue
 			}
@@ -116,77 +174,341 @@ func ParseNamedQuery(driver Driver, query string, params map[string]any) (string
 				for j < len(runes) && isParamChar(runes[j]) {
 					j++
 				}
-				name := string(runes[i+1 : j])
-
-				val, ok := params[name]
-				if !ok {
-					return "", nil, fmt.Errorf("missing parameter: %s", name)
+				if r != syntax.marker() {
+					// The other syntax stays in the text; bind rejects it
+					// if it names a parameter.
+					parsed.foreign = append(parsed.foreign, string(runes[i:j]))
+					out.WriteString(string(runes[i:j]))
+					i = j - 1
+					continue
 				}
-
-				argIndex++
-				args = append(args, val)
-
-				out.WriteString(driver.Placeholder(argIndex))
+				parsed.pieces = append(parsed.pieces, out.String())
+				parsed.names = append(parsed.names, string(runes[i+1:j]))
+				out.Reset()
 
 				i = j - 1
 				continue
 			}
 
-			// Bare colon — emit as-is
-			out.WriteRune(':')
+			// Bare marker — emit as-is
+			out.WriteRune(r)
 			continue
 		}
 
+		// Positional placeholder ($N, or ? outside PostgreSQL where it is a
+		// JSON operator): recorded so bind can reject mixing it with names
+		if parsed.positional == "" {
+			if r == '$' && i+1 < len(runes) && unicode.IsDigit(runes[i+1]) && (i == 0 || !isParamChar(runes[i-1])) {
+				j := i + 1
+				for j < len(runes) && unicode.IsDigit(runes[j]) {
+					j++
+				}
+				parsed.positional = string(runes[i:j])
+			} else if r == '?' && !lexer.postgres {
+				parsed.positional = "?"
+			}
+		}
+
 		out.WriteRune(r)
 	}
+	parsed.pieces = append(parsed.pieces, out.String())
 
-	return out.String(), args, nil
+	var sql strings.Builder
+	for i := range parsed.names {
+		sql.WriteString(parsed.pieces[i])
+		sql.WriteString(driver.Placeholder(i + 1))
+	}
+	sql.WriteString(parsed.pieces[len(parsed.names)])
+	parsed.sql = sql.String()
+	return parsed
 }
 
-func ParseNamedQueryForModel[T any](query string, params map[string]any) (string, []any, error) {
+// bind looks up the parameter values in params, expanding SliceParam values
+// into one placeholder per element. When strict, keys of params the query
+// doesn't use are an error.
+func (q *namedQuery) bind(driver Driver, params map[string]any, strict bool) (string, []any, error) {
+	if q.positional != "" && len(q.names) > 0 {
+		return "", nil, fmt.Errorf("query mixes the positional placeholder %s with named parameters, use one style", q.positional)
+	}
+	for _, param := range q.foreign {
+		if _, ok := params[param[1:]]; ok {
+			return "", nil, fmt.Errorf("query mixes :name and @name parameters at %s, use one syntax", param)
+		}
+	}
+	if strict {
+		if err := q.checkUnused(params); err != nil {
+			return "", nil, err
+		}
+	}
+	if len(q.names) == 0 {
+		return q.sql, nil, nil
+	}
+
+	args := make([]any, len(q.names))
+	expand := false
+	for i, name := range q.names {
+		val, ok := params[name]
+		if !ok {
+			return "", nil, fmt.Errorf("missing parameter: %s", name)
+		}
+		if _, ok := val.(inList); ok {
+			expand = true
+		}
+		args[i] = val
+	}
+	if !expand {
+		return q.sql, args, nil
+	}
+
+	var out strings.Builder
+	expanded := make([]any, 0, len(args))
+	for i, val := range args {
+		out.WriteString(q.pieces[i])
+		list, ok := val.(inList)
+		if !ok {
+			expanded = append(expanded, val)
+			out.WriteString(driver.Placeholder(len(expanded)))
+			continue
+		}
+		values := list.inValues()
+		if len(values) == 0 {
+			out.WriteString("(NULL)")
+		} else {
+			out.WriteString("(" + driver.JoinStringForIn(len(expanded), len(values)) + ")")
+		}
+		expanded = append(expanded, values...)
+	}
+	out.WriteString(q.pieces[len(args)])
+	return out.String(), expanded, nil
+}
+
+func (q *namedQuery) checkUnused(params map[string]any) error {
+	var unused []string
+	for name := range params {
+		if !slices.Contains(q.names, name) {
+			unused = append(unused, name)
+		}
+	}
+	if len(unused) == 0 {
+		return nil
+	}
+	slices.Sort(unused)
+	return fmt.Errorf("%w: %s", ErrUnusedParams, strings.Join(unused, ", "))
+}
+
+func ParseNamedQueryForModel[T any](query string, params map[string]any, opts ...NamedOption) (_ string, _ []any, err error) {
+	defer wrapModelError[T]("ParseNamedQueryForModel", &err)
 	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
 	if err != nil {
 		return "", nil, err
 	}
-	return ParseNamedQuery(fieldMap.Driver, query, params)
+	return parseNamed(fieldMap.Driver, query, params, opts...)
 }
 
-func SelectNamed[T any](ex Executor, query string, params map[string]any) ([]*T, error) {
-	parsed, args, err := ParseNamedQueryForModel[T](query, params)
+func SelectNamed[T any](ex Executor, query string, params map[string]any, opts ...NamedOption) (_ []*T, err error) {
+	defer wrapModelError[T]("SelectNamed", &err)
+	parsed, args, err := ParseNamedQueryForModel[T](query, params, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return Select[T](ex, parsed, args...)
 }
 
-func SelectSingleNamed[T any](ex Executor, query string, params map[string]any) (*T, error) {
-	parsed, args, err := ParseNamedQueryForModel[T](query, params)
+func SelectSingleNamed[T any](ex Executor, query string, params map[string]any, opts ...NamedOption) (_ *T, err error) {
+	defer wrapModelError[T]("SelectSingleNamed", &err)
+	parsed, args, err := ParseNamedQueryForModel[T](query, params, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return SelectSingle[T](ex, parsed, args...)
 }
 
-func UpdateNamed[T any](ex Executor, t *T, where string, params map[string]any) error {
+// SelectSingleNamedStrict is SelectSingleNamed returning ErrNotFound when no
+// row matches, see SelectSingleStrict.
+func SelectSingleNamedStrict[T any](ex Executor, query string, params map[string]any, opts ...NamedOption) (_ *T, err error) {
+	defer wrapModelError[T]("SelectSingleNamedStrict", &err)
+	parsed, args, err := ParseNamedQueryForModel[T](query, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return SelectSingleStrict[T](ex, parsed, args...)
+}
+
+// CountNamed is Count with named parameters.
+func CountNamed[T any](ex Executor, where string, params map[string]any, opts ...NamedOption) (_ int64, err error) {
+	defer wrapModelError[T]("CountNamed", &err)
+	parsed, args, err := ParseNamedQueryForModel[T](where, params, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return Count[T](ex, parsed, args...)
+}
+
+// SelectValueNamed is SelectValue with named parameters. It takes the driver
+// explicitly since no model is involved.
+func SelectValueNamed[V any](driver Driver, ex Executor, query string, params map[string]any, opts ...NamedOption) (V, error) {
+	parsed, args, err := parseNamed(driver, query, params, opts...)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	return SelectValue[V](ex, parsed, args...)
+}
+
+// ScalarQueryNamed is ScalarQuery with named parameters, using T's driver.
+func ScalarQueryNamed[T any, R any](ex Executor, query string, params map[string]any, opts ...NamedOption) (_ R, err error) {
+	defer wrapModelError[T]("ScalarQueryNamed", &err)
+	parsed, args, err := ParseNamedQueryForModel[T](query, params, opts...)
+	if err != nil {
+		var zero R
+		return zero, err
+	}
+	return ScalarQuery[T, R](ex, parsed, args...)
+}
+
+// SelectPagedNamed is SelectPaged with named parameters.
+func SelectPagedNamed[T any](ex Executor, query string, page int, perPage int, params map[string]any, opts ...NamedOption) (_ []*T, _ int64, err error) {
+	defer wrapModelError[T]("SelectPagedNamed", &err)
+	parsed, args, err := ParseNamedQueryForModel[T](query, params, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return SelectPaged[T](ex, parsed, page, perPage, args...)
+}
+
+// SelectMapsNamed is SelectMaps with named parameters. It takes the driver
+// explicitly since no model is involved.
+func SelectMapsNamed(driver Driver, ex Executor, query string, params map[string]any, opts ...NamedOption) ([]map[string]any, error) {
+	parsed, args, err := parseNamed(driver, query, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return SelectMaps(ex, parsed, args...)
+}
+
+// InsertNamed inserts t like Insert, except that the columns named in params
+// are written with the given values instead of t's fields, e.g. a generated
+// token that t does not keep. t itself is not modified; the values are bound
+// as they are, without the field's json, array or time handling.
+func InsertNamed[T any](ex Executor, t *T, params map[string]any) (_ int, err error) {
+	defer wrapModelError[T]("InsertNamed", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return 0, err
+	}
+	for column := range params {
+		if !slices.Contains(fieldMap.InsertColumns, column) {
+			return 0, fmt.Errorf("cannot insert named column %s: it is not an insert column", column)
+		}
+	}
+
+	if err := ValidateColumns[T](fieldMap.InsertColumns, fieldMap); err != nil {
+		return 0, err
+	}
+
+	if err := checkOptionalColumnsForWrite(ex, fieldMap); err != nil {
+		return 0, err
+	}
+
+	if err := fieldMap.Hooks.beforeInsert(t); err != nil {
+		return 0, err
+	}
+
+	setAutoCreateFields(fieldMap, t)
+	applyNormalizers(fieldMap, t)
+
+	insertQuery, insertColumns := insertQueryWith(fieldMap, t, params)
+	args := argsForColumns(insertColumns, fieldMap, t)
+	for i, column := range insertColumns {
+		if value, ok := params[column]; ok {
+			args[i] = value
+		}
+	}
+
+	id, err := fieldMap.Driver.InsertAndGetId(ex, insertQuery, args...)
+	if err != nil {
+		return 0, err
+	}
+	return id, fieldMap.Hooks.afterInsert(t, id)
+}
+
+func UpdateNamed[T any](ex Executor, t *T, where string, params map[string]any, opts ...NamedOption) (err error) {
+	defer wrapModelError[T]("UpdateNamed", &err)
 	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
 	if err != nil {
 		return err
 	}
-	parsedWhere, args, err := ParseNamedQuery(fieldMap.Driver, where, params)
+	parsedWhere, args, err := parseNamed(fieldMap.Driver, where, params, opts...)
 	if err != nil {
 		return err
 	}
 	return Update[T](ex, t, parsedWhere, args...)
 }
 
-func DeleteNamed(driver Driver, ex Executor, query string, params map[string]any) error {
-	parsed, args, err := ParseNamedQuery(driver, query, params)
+// UpdateNamedAffected is UpdateNamed returning the number of affected rows, see
+// UpdateAffected.
+func UpdateNamedAffected[T any](ex Executor, t *T, where string, params map[string]any, opts ...NamedOption) (_ int64, err error) {
+	defer wrapModelError[T]("UpdateNamedAffected", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return 0, err
+	}
+	parsedWhere, args, err := parseNamed(fieldMap.Driver, where, params, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return UpdateAffected[T](ex, t, parsedWhere, args...)
+}
+
+func DeleteNamed(driver Driver, ex Executor, query string, params map[string]any, opts ...NamedOption) error {
+	parsed, args, err := parseNamed(driver, query, params, opts...)
 	if err != nil {
 		return err
 	}
 	return Delete(ex, parsed, args...)
 }
 
+// DeleteNamedAffected is DeleteNamed returning the number of deleted rows, see
+// DeleteAffected.
+func DeleteNamedAffected(driver Driver, ex Executor, query string, params map[string]any, opts ...NamedOption) (int64, error) {
+	parsed, args, err := parseNamed(driver, query, params, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return DeleteAffected(ex, parsed, args...)
+}
+
+// ExecNamed runs a statement with named parameters that returns no rows, e.g.
+// an INSERT into a table without a model.
+func ExecNamed(driver Driver, ex Executor, query string, params P, opts ...NamedOption) (sql.Result, error) {
+	parsed, args, err := parseNamed(driver, query, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return debugged(ex).Exec(parsed, args...)
+}
+
+// ExecNamedFor is ExecNamed using T's driver.
+func ExecNamedFor[T any](ex Executor, query string, params P, opts ...NamedOption) (_ sql.Result, err error) {
+	defer wrapModelError[T]("ExecNamedFor", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	return ExecNamed(fieldMap.Driver, ex, query, params, opts...)
+}
+
+// InsertNamedId runs an INSERT with named parameters and returns the generated
+// id through the driver's InsertAndGetId, so on PostgreSQL and CockroachDB the
+// query must end in RETURNING id.
+func InsertNamedId(driver Driver, ex Executor, query string, params P, opts ...NamedOption) (int, error) {
+	parsed, args, err := parseNamed(driver, query, params, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return driver.InsertAndGetId(ex, parsed, args...)
+}
+
 func isParamStart(r rune) bool {
 	return r == '_' || unicode.IsLetter(r)
 }
@@ -29,6 +29,33 @@ func TestParseNamedQuery(t *testing.T) {
 		assert.Equal(t, []any{1}, args)
 	})
 
+	t.Run("slice params", func(t *testing.T) {
+		params := map[string]any{"ids": SliceParam[int]{4, 5, 6}, "status": "active"}
+
+		q, args, err := ParseNamedQuery(PostgreSQL,
+			"SELECT * FROM users WHERE status = :status AND id IN :ids AND manager_id NOT IN :ids", params)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT * FROM users WHERE status = $1 AND id IN ($2,$3,$4) AND manager_id NOT IN ($5,$6,$7)", q)
+		assert.Equal(t, []any{"active", 4, 5, 6, 4, 5, 6}, args)
+
+		q, args, err = ParseNamedQuery(MySQL, "SELECT * FROM users WHERE id IN :ids AND status = :status", params)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT * FROM users WHERE id IN (?,?,?) AND status = ?", q)
+		assert.Equal(t, []any{4, 5, 6, "active"}, args)
+
+		q, args, err = ParseNamedQuery(SQLite, "SELECT * FROM users WHERE id IN :ids AND status = :status",
+			map[string]any{"ids": SliceParam[string]{}, "status": "active"})
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT * FROM users WHERE id IN (NULL) AND status = ?", q)
+		assert.Equal(t, []any{"active"}, args)
+
+		tags := []string{"a", "b"}
+		q, args, err = ParseNamedQuery(PostgreSQL, "SELECT * FROM posts WHERE tags && :tags", map[string]any{"tags": tags})
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT * FROM posts WHERE tags && $1", q)
+		assert.Equal(t, []any{tags}, args)
+	})
+
 	t.Run("multiple params", func(t *testing.T) {
 		params := map[string]any{"id": 1, "email": "john@example.com"}
 
@@ -431,6 +458,42 @@ func TestDeleteNamed_Error(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestInsertNamed(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestDefaultedOrder]())
+	RegisterModel[TestDefaultedOrder](PostgreSQL)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("INSERT INTO test_defaulted_orders (id,item,status) VALUES (DEFAULT,$1,$2) RETURNING id").
+		WithArgs("book", "paid").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("INSERT INTO test_defaulted_orders (id,item,meta) VALUES (DEFAULT,$1,$2) RETURNING id").
+		WithArgs("override", "{}").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+
+	order := &TestDefaultedOrder{Item: "book"}
+	id, err := InsertNamed(db, order, map[string]any{"status": "paid"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, id)
+	assert.Empty(t, order.Status)
+
+	id, err = InsertNamed(db, &TestDefaultedOrder{Item: "pen", Meta: "{}"}, map[string]any{"item": "override"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, id)
+
+	_, err = InsertNamed(db, &TestDefaultedOrder{}, map[string]any{"id": 5})
+	assert.EqualError(t, err, "lit: TestDefaultedOrder.InsertNamed: cannot insert named column id: it is not an insert column")
+	_, err = InsertNamed(db, &TestDefaultedOrder{}, map[string]any{"token": "x"})
+	var modelErr *ModelError
+	require.ErrorAs(t, err, &modelErr)
+	assert.Equal(t, "TestDefaultedOrder", modelErr.Model)
+	assert.Equal(t, "InsertNamed", modelErr.Op)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestUpdateNamed(t *testing.T) {
 	t.Run("PostgreSQL", func(t *testing.T) {
 		delete(StructToFieldMap, reflect.TypeFor[TestUser]())
@@ -647,3 +710,342 @@ func TestTypeP(t *testing.T) {
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestParseNamedQueryStrict(t *testing.T) {
+	query := "SELECT * FROM users WHERE id = :id"
+
+	q, args, err := ParseNamedQueryStrict(PostgreSQL, query, P{"id": 1})
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE id = $1", q)
+	assert.Equal(t, []any{1}, args)
+
+	_, _, err = ParseNamedQueryStrict(PostgreSQL, query, P{"id": 1, "typo": 2, "extra": 3})
+	assert.ErrorIs(t, err, ErrUnusedParams)
+	assert.EqualError(t, err, "lit: unused named parameters: extra, typo")
+
+	_, _, err = ParseNamedQueryStrict(MySQL, "SELECT 1", P{"id": 1})
+	assert.ErrorIs(t, err, ErrUnusedParams)
+
+	// The lenient parser still ignores extra keys.
+	_, _, err = ParseNamedQuery(PostgreSQL, query, P{"id": 1, "typo": 2})
+	assert.NoError(t, err)
+}
+
+func TestSetStrictNamedParams(t *testing.T) {
+	SetStrictNamedParams(true)
+	defer SetStrictNamedParams(false)
+
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+
+	_, err := SelectNamed[TestUser](nil, "SELECT * FROM test_users WHERE id = :id", P{"id": 1, "usre_id": 2})
+	assert.EqualError(t, err, "lit: TestUser.SelectNamed: unused named parameters: usre_id")
+}
+
+func TestWithStrictParams(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+
+	params := P{"id": 1, "usre_id": 2, "stauts": 3}
+
+	_, err := SelectNamed[TestUser](nil, "SELECT * FROM test_users WHERE id = :id", params, WithStrictParams())
+	assert.EqualError(t, err, "lit: TestUser.SelectNamed: unused named parameters: stauts, usre_id")
+
+	err = UpdateNamed(nil, &TestUser{}, "id = :id", params, WithStrictParams())
+	assert.ErrorIs(t, err, ErrUnusedParams)
+
+	err = DeleteNamed(PostgreSQL, nil, "DELETE FROM test_users WHERE id = :id", params, WithStrictParams())
+	assert.ErrorIs(t, err, ErrUnusedParams)
+
+	// Without the option the extra keys are ignored.
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	mock.ExpectExec("DELETE FROM test_users WHERE id = \\$1").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, DeleteNamed(PostgreSQL, db, "DELETE FROM test_users WHERE id = :id", params))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParseNamedQuery_Comments(t *testing.T) {
+	params := P{"id": 1, "status": "active"}
+
+	t.Run("line comment", func(t *testing.T) {
+		q, args, err := ParseNamedQuery(PostgreSQL,
+			"SELECT * FROM users -- filter by :missing later\nWHERE id = :id", params)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT * FROM users -- filter by :missing later\nWHERE id = $1", q)
+		assert.Equal(t, []any{1}, args)
+	})
+
+	t.Run("line comment at end", func(t *testing.T) {
+		q, args, err := ParseNamedQuery(MySQL, "SELECT * FROM users WHERE id = :id -- :cleanup", params)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT * FROM users WHERE id = ? -- :cleanup", q)
+		assert.Equal(t, []any{1}, args)
+	})
+
+	t.Run("block comment", func(t *testing.T) {
+		q, args, err := ParseNamedQuery(PostgreSQL,
+			"SELECT * FROM users WHERE /* TODO :cleanup */id = :id AND status = /* :old */:status", params)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT * FROM users WHERE /* TODO :cleanup */id = $1 AND status = /* :old */$2", q)
+		assert.Equal(t, []any{1, "active"}, args)
+	})
+
+	t.Run("nested block comment", func(t *testing.T) {
+		q, args, err := ParseNamedQuery(PostgreSQL,
+			"SELECT 1 /* outer /* inner :a */ still :b */ WHERE id = :id", params)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT 1 /* outer /* inner :a */ still :b */ WHERE id = $1", q)
+		assert.Equal(t, []any{1}, args)
+	})
+
+	t.Run("unterminated block comment", func(t *testing.T) {
+		q, args, err := ParseNamedQuery(SQLite, "SELECT 1 WHERE id = :id /* :x", params)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT 1 WHERE id = ? /* :x", q)
+		assert.Equal(t, []any{1}, args)
+	})
+
+	t.Run("hash comment on MySQL only", func(t *testing.T) {
+		q, _, err := ParseNamedQuery(MySQL, "SELECT * FROM users # by :missing\nWHERE id = :id", params)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT * FROM users # by :missing\nWHERE id = ?", q)
+
+		q, args, err := ParseNamedQuery(PostgreSQL, "SELECT flags # :status FROM users WHERE id = :id", params)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT flags # $1 FROM users WHERE id = $2", q)
+		assert.Equal(t, []any{"active", 1}, args)
+	})
+
+	t.Run("comment markers in strings", func(t *testing.T) {
+		q, args, err := ParseNamedQuery(PostgreSQL, "SELECT '-- not a comment' WHERE id = :id", params)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT '-- not a comment' WHERE id = $1", q)
+		assert.Equal(t, []any{1}, args)
+	})
+}
+
+func TestParseNamedQuery_DollarQuotes(t *testing.T) {
+	params := P{"id": 1}
+
+	t.Run("plain dollar quote", func(t *testing.T) {
+		q, args, err := ParseNamedQuery(PostgreSQL, "SELECT $$it's :not a param$$ WHERE id = :id", params)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT $$it's :not a param$$ WHERE id = $1", q)
+		assert.Equal(t, []any{1}, args)
+	})
+
+	t.Run("nested-looking tags", func(t *testing.T) {
+		q, args, err := ParseNamedQuery(PostgreSQL,
+			"DO $outer$ BEGIN PERFORM $inner$ :a $inner$; END :b $outer$; SELECT :id", params)
+		require.NoError(t, err)
+		assert.Equal(t, "DO $outer$ BEGIN PERFORM $inner$ :a $inner$; END :b $outer$; SELECT $1", q)
+		assert.Equal(t, []any{1}, args)
+	})
+
+	t.Run("positional literal in body", func(t *testing.T) {
+		q, _, err := ParseNamedQuery(CockroachDB, "SELECT $tag$costs $1$tag$, :id", params)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT $tag$costs $1$tag$, $1", q)
+	})
+
+	t.Run("unterminated dollar quote", func(t *testing.T) {
+		q, _, err := ParseNamedQuery(PostgreSQL, "SELECT $$ :id", params)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT $$ $1", q)
+	})
+
+	t.Run("not quoted on MySQL", func(t *testing.T) {
+		q, _, err := ParseNamedQuery(MySQL, "SELECT '$$', $$ :id $$", params)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT '$$', $$ ? $$", q)
+	})
+}
+
+func TestParseNamedQueryWithSyntax(t *testing.T) {
+	t.Run("at syntax", func(t *testing.T) {
+		q, args, err := ParseNamedQueryWithSyntax(PostgreSQL,
+			"SELECT created_at::date, '@skip' FROM users WHERE id = @id AND status = @status", P{"id": 1, "status": "active"}, AtSyntax)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT created_at::date, '@skip' FROM users WHERE id = $1 AND status = $2", q)
+		assert.Equal(t, []any{1, "active"}, args)
+	})
+
+	t.Run("system variables are left alone", func(t *testing.T) {
+		q, args, err := ParseNamedQueryWithSyntax(MySQL, "SELECT @@version WHERE id = @id", P{"id": 1}, AtSyntax)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT @@version WHERE id = ?", q)
+		assert.Equal(t, []any{1}, args)
+	})
+
+	t.Run("mixed syntaxes", func(t *testing.T) {
+		_, _, err := ParseNamedQueryWithSyntax(PostgreSQL, "WHERE id = @id AND status = :status", P{"id": 1, "status": "active"}, AtSyntax)
+		assert.EqualError(t, err, "query mixes :name and @name parameters at :status, use one syntax")
+
+		_, _, err = ParseNamedQuery(PostgreSQL, "WHERE id = :id AND status = @status", P{"id": 1, "status": "active"})
+		assert.EqualError(t, err, "query mixes :name and @name parameters at @status, use one syntax")
+	})
+
+	t.Run("user variables with colon syntax", func(t *testing.T) {
+		q, args, err := ParseNamedQuery(MySQL, "SELECT @rank := @rank + 1 FROM users WHERE id = :id", P{"id": 1})
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT @rank := @rank + 1 FROM users WHERE id = ?", q)
+		assert.Equal(t, []any{1}, args)
+	})
+
+	t.Run("cached per syntax", func(t *testing.T) {
+		query := "SELECT * FROM users WHERE id = @id"
+		q, args, err := ParseNamedQueryWithSyntax(SQLite, query, P{"id": 1}, AtSyntax)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT * FROM users WHERE id = ?", q)
+		assert.Equal(t, []any{1}, args)
+
+		q, args, err = ParseNamedQueryWithSyntax(SQLite, query, P{}, ColonSyntax)
+		require.NoError(t, err)
+		assert.Equal(t, query, q)
+		assert.Empty(t, args)
+	})
+}
+
+func TestSelectNamed_AtSyntax(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "email"}).
+		AddRow(1, "John", "Doe", "john@example.com")
+	mock.ExpectQuery("SELECT \\* FROM test_users WHERE last_name = \\$1").
+		WithArgs("Doe").
+		WillReturnRows(rows)
+
+	users, err := SelectNamed[TestUser](db,
+		"SELECT * FROM test_users WHERE last_name = @last_name",
+		P{"last_name": "Doe"}, WithParamSyntax(AtSyntax))
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParseNamedQuery_MixedPlaceholders(t *testing.T) {
+	params := P{"status": "active"}
+
+	t.Run("dollar placeholder", func(t *testing.T) {
+		_, _, err := ParseNamedQuery(PostgreSQL, "SELECT * FROM users WHERE id = $1 AND status = :status", params)
+		assert.EqualError(t, err, "query mixes the positional placeholder $1 with named parameters, use one style")
+	})
+
+	t.Run("question mark", func(t *testing.T) {
+		_, _, err := ParseNamedQuery(MySQL, "SELECT * FROM users WHERE id = ? AND status = :status", params)
+		assert.EqualError(t, err, "query mixes the positional placeholder ? with named parameters, use one style")
+
+		_, _, err = ParseNamedQuery(SQLite, "SELECT * FROM users WHERE status = :status AND id = ?", params)
+		assert.Error(t, err)
+	})
+
+	t.Run("PostgreSQL JSON operators", func(t *testing.T) {
+		for _, op := range []string{"?", "?|", "?&"} {
+			q, args, err := ParseNamedQuery(PostgreSQL, "SELECT * FROM users WHERE tags "+op+" 'admin' AND status = :status", params)
+			require.NoError(t, err, op)
+			assert.Equal(t, "SELECT * FROM users WHERE tags "+op+" 'admin' AND status = $1", q)
+			assert.Equal(t, []any{"active"}, args)
+		}
+	})
+
+	t.Run("not placeholders", func(t *testing.T) {
+		queries := []string{
+			"SELECT '$1 or ?' FROM users WHERE status = :status",
+			"SELECT price$1 FROM users WHERE status = :status",
+			"SELECT 1 -- id = ?\nWHERE status = :status",
+			"SELECT \"who?\" FROM users WHERE status = :status",
+		}
+		for _, query := range queries {
+			_, _, err := ParseNamedQuery(SQLite, query, params)
+			assert.NoError(t, err, query)
+		}
+
+		_, _, err := ParseNamedQuery(PostgreSQL, "SELECT $$ $1 $$ WHERE status = :status", params)
+		assert.NoError(t, err)
+	})
+
+	t.Run("positional only", func(t *testing.T) {
+		q, args, err := ParseNamedQuery(PostgreSQL, "SELECT * FROM users WHERE id = $1", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT * FROM users WHERE id = $1", q)
+		assert.Empty(t, args)
+	})
+}
+
+func TestExecNamed(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO audit_log (actor, action) VALUES ($1, $2)").
+		WithArgs("john", "login").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	result, err := ExecNamed(PostgreSQL, db, "INSERT INTO audit_log (actor, action) VALUES (:actor, :action)", P{"actor": "john", "action": "login"})
+	require.NoError(t, err)
+	affected, err := result.RowsAffected()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+
+	_, err = ExecNamed(PostgreSQL, db, "INSERT INTO audit_log (actor) VALUES (:actor)", P{})
+	assert.EqualError(t, err, "missing parameter: actor")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExecNamedFor(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](MySQL)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE test_users SET email = LOWER(email) WHERE id IN (?,?)").
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	_, err = ExecNamedFor[TestUser](db, "UPDATE test_users SET email = LOWER(email) WHERE id IN :ids", P{"ids": SliceParam[int]{1, 2}})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertNamedId(t *testing.T) {
+	t.Run("PostgreSQL", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("INSERT INTO audit_log (actor, action) VALUES ($1, $2) RETURNING id").
+			WithArgs("john", "login").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+
+		id, err := InsertNamedId(PostgreSQL, db, "INSERT INTO audit_log (actor, action) VALUES (:actor, :action) RETURNING id", P{"actor": "john", "action": "login"})
+		require.NoError(t, err)
+		assert.Equal(t, 7, id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("MySQL", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("INSERT INTO audit_log (actor, action) VALUES (?, ?)").
+			WithArgs("john", "login").
+			WillReturnResult(sqlmock.NewResult(8, 1))
+
+		id, err := InsertNamedId(MySQL, db, "INSERT INTO audit_log (actor, action) VALUES (:actor, :action)", P{"actor": "john", "action": "login"})
+		require.NoError(t, err)
+		assert.Equal(t, 8, id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,129 @@
+package pgx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/tracewayapp/lit/v2"
+)
+
+// Conn is implemented by *pgxpool.Pool, *pgx.Conn and pgx.Tx. lit.Executor
+// returns *sql.Rows and *sql.Row, which pgx can't produce, so the functions
+// below take a Conn instead and run on pgx's native protocol without
+// database/sql in between.
+type Conn interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Exec runs query on conn and returns the number of affected rows.
+func Exec(ctx context.Context, conn Conn, query string, args ...any) (int64, error) {
+	tag, err := conn.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// InsertAndGetId runs an INSERT ending in RETURNING id and returns the id.
+func InsertAndGetId(ctx context.Context, conn Conn, query string, args ...any) (int, error) {
+	var id int
+	if err := conn.QueryRow(ctx, query, args...).Scan(&id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// Insert inserts t with its model's INSERT query and returns the generated
+// integer id, or 0 for other ids. Insert hooks, autocreate timestamps and
+// normalizers run as for lit.Insert, but columns tagged default are always
+// written. T must be registered for PostgreSQL or CockroachDB.
+func Insert[T any](ctx context.Context, conn Conn, t *T) (int, error) {
+	fieldMap, err := lit.GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return 0, err
+	}
+	if fieldMap.Driver != lit.PostgreSQL && fieldMap.Driver != lit.CockroachDB {
+		return 0, fmt.Errorf("model %s is registered for %s, pgx needs PostgreSQL or CockroachDB", reflect.TypeFor[T]().Name(), fieldMap.Driver.Name())
+	}
+	_, _, values, err := lit.CopyRows([]*T{t})
+	if err != nil {
+		return 0, err
+	}
+
+	id := 0
+	if strings.HasSuffix(fieldMap.InsertQuery, " RETURNING id") {
+		id, err = InsertAndGetId(ctx, conn, fieldMap.InsertQuery, values[0]...)
+	} else {
+		_, err = Exec(ctx, conn, fieldMap.InsertQuery, values[0]...)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if fieldMap.Hooks.AfterInsert != nil {
+		return id, fieldMap.Hooks.AfterInsert(t, id)
+	}
+	return id, nil
+}
+
+// Select runs query on conn and scans every row into a T, like lit.Select.
+func Select[T any](ctx context.Context, conn Conn, query string, args ...any) ([]*T, error) {
+	fieldMap, err := lit.GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := []string{}
+	for _, field := range rows.FieldDescriptions() {
+		columns = append(columns, field.Name)
+	}
+	if err := lit.ValidateColumns[T](columns, fieldMap); err != nil {
+		return nil, err
+	}
+
+	list := []*T{}
+	for rows.Next() {
+		var t T
+		dest := *lit.GetPointersForColumns(columns, fieldMap, &t)
+		for i, d := range dest {
+			if s, ok := d.(sql.Scanner); ok && reflect.ValueOf(d).Kind() != reflect.Pointer {
+				dest[i] = &scanner{s}
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		list = append(list, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// scanner hands a value-typed sql.Scanner from lit.GetPointersForColumns to
+// pgx as a pointer, the form pgx.Rows implementations such as pgxmock expect.
+type scanner struct {
+	sql.Scanner
+}
+
+// SelectSingle is Select returning the first row, or nil when there is none.
+func SelectSingle[T any](ctx context.Context, conn Conn, query string, args ...any) (*T, error) {
+	list, err := Select[T](ctx, conn, query, args...)
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return list[0], nil
+}
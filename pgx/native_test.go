@@ -0,0 +1,100 @@
+package pgx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tracewayapp/lit/v2"
+)
+
+type nativeUser struct {
+	Id       int
+	Email    string `lit:"email,normalize=lower"`
+	Nickname *string
+}
+
+func TestInsert(t *testing.T) {
+	lit.RegisterModel[nativeUser](lit.PostgreSQL)
+	defer lit.DeregisterModel[nativeUser]()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(`INSERT INTO native_users \(id,email,nickname\) VALUES \(DEFAULT,\$1,\$2\) RETURNING id`).
+		WithArgs("jane@example.com", nil).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(7))
+
+	user := &nativeUser{Email: "Jane@Example.com"}
+	id, err := Insert(context.Background(), mock, user)
+	require.NoError(t, err)
+	assert.Equal(t, 7, id)
+	assert.Equal(t, "jane@example.com", user.Email)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsert_WrongDriver(t *testing.T) {
+	lit.RegisterModel[nativeUser](lit.MySQL)
+	defer lit.DeregisterModel[nativeUser]()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	_, err = Insert(context.Background(), mock, &nativeUser{})
+	assert.EqualError(t, err, "model nativeUser is registered for MySQL, pgx needs PostgreSQL or CockroachDB")
+}
+
+func TestSelect(t *testing.T) {
+	lit.RegisterModel[nativeUser](lit.PostgreSQL)
+	defer lit.DeregisterModel[nativeUser]()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT id,email,nickname FROM native_users").
+		WillReturnRows(pgxmock.NewRows([]string{"id", "email", "nickname"}).
+			AddRow(1, "john@example.com", "jj").
+			AddRow(2, "jane@example.com", nil))
+	mock.ExpectQuery("SELECT id,email,nickname FROM native_users WHERE id = \\$1").
+		WithArgs(3).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "email", "nickname"}))
+	mock.ExpectQuery("SELECT id,secret FROM native_users").
+		WillReturnRows(pgxmock.NewRows([]string{"id", "secret"}).AddRow(1, "x"))
+
+	users, err := Select[nativeUser](context.Background(), mock, "SELECT id,email,nickname FROM native_users")
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "john@example.com", users[0].Email)
+	require.NotNil(t, users[0].Nickname)
+	assert.Equal(t, "jj", *users[0].Nickname)
+	assert.Nil(t, users[1].Nickname)
+
+	user, err := SelectSingle[nativeUser](context.Background(), mock, "SELECT id,email,nickname FROM native_users WHERE id = $1", 3)
+	require.NoError(t, err)
+	assert.Nil(t, user)
+
+	_, err = Select[nativeUser](context.Background(), mock, "SELECT id,secret FROM native_users")
+	assert.EqualError(t, err, "invalid column that is not found in the struct: secret")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExec(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec("DELETE FROM native_users").
+		WithArgs(1).
+		WillReturnResult(pgxmock.NewResult("DELETE", 2))
+
+	n, err := Exec(context.Background(), mock, "DELETE FROM native_users WHERE id = $1", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,37 @@
+// Package pgx runs lit models over pgx's native protocol: bulk loads through
+// COPY, taking the table, columns and row values from lit.CopyRows, plus
+// Insert, Select and Exec on a pgx pool, connection or transaction.
+package pgx
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/tracewayapp/lit/v2"
+)
+
+// CopyFromer is implemented by *pgxpool.Pool, *pgx.Conn and pgx.Tx.
+type CopyFromer interface {
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+// CopyInsertPool bulk inserts rows with COPY on a connection acquired from
+// pool and returns the number of rows written. Integer ids are left to their
+// sequence, like lit.CopyInsert.
+func CopyInsertPool[T any](pool *pgxpool.Pool, rows []*T) (int64, error) {
+	return CopyInsert(context.Background(), pool, rows)
+}
+
+// CopyInsert is CopyInsertPool for any CopyFromer, e.g. a transaction.
+func CopyInsert[T any](ctx context.Context, conn CopyFromer, rows []*T) (int64, error) {
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	table, columns, values, err := lit.CopyRows(rows)
+	if err != nil {
+		return 0, err
+	}
+	return conn.CopyFrom(ctx, pgx.Identifier(strings.Split(table, ".")), columns, pgx.CopyFromRows(values))
+}
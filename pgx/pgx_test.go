@@ -0,0 +1,69 @@
+package pgx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tracewayapp/lit/v2"
+)
+
+type copiedUser struct {
+	Id        int
+	FirstName string
+	Email     string
+}
+
+type auditEntry struct {
+	Id     int
+	Action string
+}
+
+func TestCopyInsert(t *testing.T) {
+	lit.RegisterModel[copiedUser](lit.PostgreSQL)
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectCopyFrom(pgx.Identifier{"copied_users"}, []string{"first_name", "email"}).
+		WillReturnResult(2)
+
+	n, err := CopyInsert(context.Background(), mock, []*copiedUser{
+		{FirstName: "John", Email: "john@example.com"},
+		{FirstName: "Jane", Email: "jane@example.com"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCopyInsert_SchemaQualifiedTable(t *testing.T) {
+	lit.RegisterModelWithNaming[auditEntry](lit.PostgreSQL, lit.NewSchemaStrategy("audit", lit.DefaultDbNamingStrategy{}))
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectCopyFrom(pgx.Identifier{"audit", "audit_entrys"}, []string{"action"}).
+		WillReturnResult(1)
+
+	n, err := CopyInsert(context.Background(), mock, []*auditEntry{{Action: "login"}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCopyInsert_NotRegistered(t *testing.T) {
+	type unregistered struct{ Id int }
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	_, err = CopyInsert(context.Background(), mock, []*unregistered{{}})
+	assert.ErrorAs(t, err, &lit.NotRegisteredError{})
+}
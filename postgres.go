@@ -2,8 +2,10 @@ package lit
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type pgDriver struct{}
@@ -18,7 +20,7 @@ func (d *pgDriver) GenerateInsertQuery(tableName string, columnKeys []string, ha
 	var insertQuery strings.Builder
 
 	insertQuery.WriteString("INSERT INTO ")
-	insertQuery.WriteString(pgEscapeReserved(tableName))
+	insertQuery.WriteString(escapeTableName(tableName, pgEscapeReserved))
 	insertQuery.WriteString(" (")
 
 	totalKeys := len(columnKeys)
@@ -53,7 +55,7 @@ func (d *pgDriver) GenerateInsertQuery(tableName string, columnKeys []string, ha
 func (d *pgDriver) GenerateUpdateQuery(tableName string, columnKeys []string) string {
 	var updateQuery strings.Builder
 	updateQuery.WriteString("UPDATE ")
-	updateQuery.WriteString(pgEscapeReserved(tableName))
+	updateQuery.WriteString(escapeTableName(tableName, pgEscapeReserved))
 	updateQuery.WriteString(" SET ")
 
 	totalKeys := len(columnKeys)
@@ -71,7 +73,7 @@ func (d *pgDriver) GenerateUpdateQuery(tableName string, columnKeys []string) st
 }
 
 func (d *pgDriver) InsertAndGetId(ex Executor, query string, args ...any) (int, error) {
-	row := ex.QueryRow(query, args...)
+	row := debugged(ex).QueryRow(query, args...)
 	var id int
 	err := row.Scan(&id)
 	if err != nil {
@@ -94,6 +96,10 @@ func (d *pgDriver) JoinStringForIn(offset int, count int) string {
 	return pgJoinStringForIn(offset, count)
 }
 
+func (d *pgDriver) ForShareClause() string { return "FOR SHARE" }
+
+func (d *pgDriver) NormalizeTime(t time.Time) time.Time { return t.Round(0).UTC() }
+
 // Deprecated: Use PostgreSQL variable directly. PgInsertUpdateQueryGenerator is kept for backward compatibility.
 type PgInsertUpdateQueryGenerator = pgDriver
 
@@ -102,27 +108,23 @@ func pgRenumberPlaceholders(where string, offset int) string {
 		return where
 	}
 
+	runes := []rune(where)
 	var newWhere strings.Builder
-	parsingIdentifier := false
-
-	for _, c := range where {
-		if c == '$' {
-			parsingIdentifier = true
-			newWhere.WriteRune(c)
-		} else if parsingIdentifier {
-			if c >= '0' && c <= '9' {
-				continue
-			} else {
-				parsingIdentifier = false
-				offset++
-				newWhere.WriteString(strconv.Itoa(offset))
-				newWhere.WriteRune(c)
-			}
-		} else {
-			newWhere.WriteRune(c)
+
+	for i := 0; i < len(runes); i++ {
+		if end := pgDollarQuoteEnd(runes, i); end != -1 {
+			newWhere.WriteString(string(runes[i:end]))
+			i = end - 1
+			continue
+		}
+
+		newWhere.WriteRune(runes[i])
+		if runes[i] != '$' {
+			continue
+		}
+		for i+1 < len(runes) && runes[i+1] >= '0' && runes[i+1] <= '9' {
+			i++
 		}
-	}
-	if parsingIdentifier {
 		offset++
 		newWhere.WriteString(strconv.Itoa(offset))
 	}
@@ -130,6 +132,35 @@ func pgRenumberPlaceholders(where string, offset int) string {
 	return newWhere.String()
 }
 
+// pgDollarQuoteEnd returns the index just past the dollar-quoted string
+// ($$...$$ or $tag$...$tag$) starting at runes[i], or -1 if none starts there.
+// Other tags inside the body are part of the string, so only the opening tag
+// closes it.
+func pgDollarQuoteEnd(runes []rune, i int) int {
+	if runes[i] != '$' || (i > 0 && isParamChar(runes[i-1])) {
+		return -1
+	}
+
+	j := i + 1
+	if j < len(runes) && runes[j] != '$' && !isParamStart(runes[j]) {
+		return -1
+	}
+	for j < len(runes) && isParamChar(runes[j]) {
+		j++
+	}
+	if j >= len(runes) || runes[j] != '$' {
+		return -1
+	}
+
+	tag := runes[i : j+1]
+	for k := j + 1; k+len(tag) <= len(runes); k++ {
+		if slices.Equal(runes[k:k+len(tag)], tag) {
+			return k + len(tag)
+		}
+	}
+	return -1
+}
+
 func pgJoinStringForIn(offset int, count int) string {
 	var sb strings.Builder
 	for i := 0; i < count; i++ {
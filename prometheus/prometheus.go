@@ -0,0 +1,125 @@
+// Package prometheus records lit queries as Prometheus metrics through a
+// lit.QueryHook: a statement counter, an error counter and a duration
+// histogram, labelled by operation, model and driver.
+package prometheus
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/tracewayapp/lit/v2"
+)
+
+type config struct {
+	registerer prom.Registerer
+}
+
+// Option configures NewPrometheusHook.
+type Option func(*config)
+
+// WithRegisterer registers the metrics with reg instead of
+// prometheus.DefaultRegisterer.
+func WithRegisterer(reg prom.Registerer) Option {
+	return func(c *config) {
+		c.registerer = reg
+	}
+}
+
+// Hook is a lit.QueryHook counting and timing statements by operation, model
+// and driver.
+type Hook struct {
+	queries  *prom.CounterVec
+	errors   *prom.CounterVec
+	duration *prom.HistogramVec
+	models   map[string]modelLabels
+}
+
+// modelLabels holds the model and driver labels of a table.
+type modelLabels struct {
+	model  string
+	driver string
+}
+
+var labels = []string{"operation", "model", "driver"}
+
+// NewPrometheusHook registers <namespace>_queries_total,
+// <namespace>_query_duration_seconds and <namespace>_query_errors_total, all
+// labelled with operation ("select", "insert", "update", "delete" or
+// "other"), model and driver. The model is the registered lit model whose
+// table the statement targets, empty when there is none. Models are looked up
+// once here, so register them before creating the hook. When several models
+// share a table, the one whose type name sorts first labels it. Use it with
+// lit.NewHookedExecutor:
+//
+//	hook, err := prometheus.NewPrometheusHook("lit")
+//	ex := lit.NewHookedExecutor(db, hook)
+func NewPrometheusHook(namespace string, opts ...Option) (lit.QueryHook, error) {
+	c := config{registerer: prom.DefaultRegisterer}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	h := &Hook{
+		queries: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Name:      "queries_total",
+			Help:      "Number of statements run.",
+		}, labels),
+		errors: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Name:      "query_errors_total",
+			Help:      "Number of statements that failed.",
+		}, labels),
+		duration: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace: namespace,
+			Name:      "query_duration_seconds",
+			Help:      "Statement duration in seconds.",
+			Buckets:   prom.DefBuckets,
+		}, labels),
+		models: indexModels(),
+	}
+	for _, collector := range []prom.Collector{h.queries, h.errors, h.duration} {
+		if err := c.registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+func (h *Hook) BeforeQuery(ctx context.Context, query string, args []any) {}
+
+func (h *Hook) AfterQuery(ctx context.Context, query string, args []any, duration time.Duration, err error) {
+	operation, table := lit.DescribeQuery(query)
+	switch operation {
+	case "SELECT", "INSERT", "UPDATE", "DELETE":
+		operation = strings.ToLower(operation)
+	default:
+		operation = "other"
+	}
+	m := h.models[table]
+
+	h.queries.WithLabelValues(operation, m.model, m.driver).Inc()
+	h.duration.WithLabelValues(operation, m.model, m.driver).Observe(duration.Seconds())
+	if err != nil && err != sql.ErrNoRows {
+		h.errors.WithLabelValues(operation, m.model, m.driver).Inc()
+	}
+}
+
+// indexModels maps the table of every registered lit model to its labels.
+func indexModels() map[string]modelLabels {
+	models := map[string]modelLabels{}
+	for t, fieldMap := range lit.StructToFieldMap {
+		labels := modelLabels{model: t.Name()}
+		if fieldMap.Driver != nil {
+			labels.driver = fieldMap.Driver.Name()
+		}
+		if existing, ok := models[fieldMap.TableName]; ok && existing.model <= labels.model {
+			continue
+		}
+		models[fieldMap.TableName] = labels
+	}
+	return models
+}
@@ -0,0 +1,80 @@
+package prometheus
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tracewayapp/lit/v2"
+)
+
+type Account struct {
+	Id    int
+	Email string
+}
+
+func TestPrometheusHook(t *testing.T) {
+	lit.RegisterModel[Account](lit.PostgreSQL)
+	defer lit.DeregisterModel[Account]()
+
+	reg := prom.NewRegistry()
+	hook, err := NewPrometheusHook("lit", WithRegisterer(reg))
+	require.NoError(t, err)
+	h := hook.(*Hook)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("DELETE FROM accounts").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM accounts").WillReturnError(errors.New("boom"))
+	mock.ExpectExec("DELETE FROM sessions").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ex := lit.NewHookedExecutor(db, hook)
+	_, err = ex.Exec("DELETE FROM accounts WHERE id = $1", 1)
+	require.NoError(t, err)
+	_, err = ex.Exec("DELETE FROM accounts WHERE id = $1", 2)
+	require.Error(t, err)
+	_, err = ex.Exec("DELETE FROM sessions WHERE id = $1", 3)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(h.queries.WithLabelValues("delete", "Account", "PostgreSQL")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(h.errors.WithLabelValues("delete", "Account", "PostgreSQL")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(h.queries.WithLabelValues("delete", "", "")))
+	assert.Equal(t, 2, testutil.CollectAndCount(h.duration))
+
+	names := []string{}
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		names = append(names, family.GetName())
+	}
+	assert.ElementsMatch(t, []string{"lit_queries_total", "lit_query_errors_total", "lit_query_duration_seconds"}, names)
+
+	_, err = NewPrometheusHook("lit", WithRegisterer(reg))
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+type AccountView struct {
+	Id    int
+	Email string
+}
+
+func TestPrometheusHook_SharedTable(t *testing.T) {
+	lit.RegisterModel[Account](lit.PostgreSQL)
+	defer lit.DeregisterModel[Account]()
+	lit.RegisterModelWithFuncs[AccountView](lit.MySQL, func(string) string { return "accounts" }, nil)
+	defer lit.DeregisterModel[AccountView]()
+
+	for range 10 {
+		hook, err := NewPrometheusHook("lit", WithRegisterer(prom.NewRegistry()))
+		require.NoError(t, err)
+		assert.Equal(t, modelLabels{model: "Account", driver: "PostgreSQL"}, hook.(*Hook).models["accounts"])
+	}
+}
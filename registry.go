@@ -0,0 +1,46 @@
+package lit
+
+import (
+	"reflect"
+	"slices"
+	"sort"
+)
+
+// ModelInfo describes a registered model, see ListRegisteredModels.
+type ModelInfo struct {
+	Type          reflect.Type
+	TypeName      string
+	TableName     string
+	Driver        string
+	ColumnKeys    []string
+	HasIntId      bool
+	HasSoftDelete bool
+}
+
+// ListRegisteredModels returns every registered model, sorted by type. Use it
+// in health checks or tooling that needs to know what lit will accept.
+func ListRegisteredModels() []ModelInfo {
+	types := make([]reflect.Type, 0, len(StructToFieldMap))
+	for t := range StructToFieldMap {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].String() < types[j].String() })
+
+	models := make([]ModelInfo, 0, len(types))
+	for _, t := range types {
+		fieldMap := StructToFieldMap[t]
+		info := ModelInfo{
+			Type:          t,
+			TypeName:      t.Name(),
+			TableName:     fieldMap.TableName,
+			ColumnKeys:    slices.Clone(fieldMap.ColumnKeys),
+			HasIntId:      fieldMap.HasIntId,
+			HasSoftDelete: fieldMap.SoftDeleteColumn != "",
+		}
+		if fieldMap.Driver != nil {
+			info.Driver = fieldMap.Driver.Name()
+		}
+		models = append(models, info)
+	}
+	return models
+}
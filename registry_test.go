@@ -0,0 +1,39 @@
+package lit
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListRegisteredModels(t *testing.T) {
+	saved := maps.Clone(StructToFieldMap)
+	defer func() { StructToFieldMap = saved }()
+
+	DeregisterAll()
+	RegisterModel[TestUser](PostgreSQL)
+	RegisterModel[TestArchivedUser](MySQL)
+	RegisterModel[TestProduct](SQLite)
+
+	models := ListRegisteredModels()
+	require.Len(t, models, 3)
+
+	assert.Equal(t, "TestArchivedUser", models[0].TypeName)
+	assert.Equal(t, "test_archived_users", models[0].TableName)
+	assert.Equal(t, "MySQL", models[0].Driver)
+	assert.True(t, models[0].HasSoftDelete)
+
+	assert.Equal(t, "TestProduct", models[1].TypeName)
+	assert.False(t, models[1].HasIntId)
+
+	assert.Equal(t, "TestUser", models[2].TypeName)
+	assert.Equal(t, "PostgreSQL", models[2].Driver)
+	assert.Equal(t, []string{"id", "first_name", "last_name", "email"}, models[2].ColumnKeys)
+	assert.True(t, models[2].HasIntId)
+	assert.False(t, models[2].HasSoftDelete)
+
+	models[2].ColumnKeys[0] = "changed"
+	assert.Equal(t, "id", StructToFieldMap[models[2].Type].ColumnKeys[0])
+}
@@ -0,0 +1,98 @@
+package lit
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+const returningIdSuffix = " RETURNING id"
+
+// InsertReturning inserts t and reads returnCols (e.g. database generated
+// timestamps or tokens) back into it. Drivers whose insert query ends in
+// RETURNING id (PostgreSQL, CockroachDB) return the columns from the INSERT
+// itself; the others select them by id right after inserting.
+func InsertReturning[T any](ex Executor, t *T, returnCols []string) (err error) {
+	defer wrapModelError[T]("InsertReturning", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return err
+	}
+
+	if err := ValidateColumns[T](fieldMap.InsertColumns, fieldMap); err != nil {
+		return err
+	}
+	if err := ValidateColumns[T](returnCols, fieldMap); err != nil {
+		return err
+	}
+
+	if err := checkOptionalColumnsForWrite(ex, fieldMap); err != nil {
+		return err
+	}
+
+	if err := fieldMap.Hooks.beforeInsert(t); err != nil {
+		return err
+	}
+
+	setAutoCreateFields(fieldMap, t)
+	applyNormalizers(fieldMap, t)
+
+	if fieldMap.HasIntId && !slices.Contains(returnCols, "id") {
+		returnCols = append([]string{"id"}, returnCols...)
+	}
+
+	if err := insertAndReadBack(ex, fieldMap, t, returnCols); err != nil {
+		return err
+	}
+
+	id := 0
+	if fieldMap.HasIntId {
+		id = int(fieldMap.field(reflect.ValueOf(t).Elem(), fieldMap.ColumnsMap["id"]).Int())
+	}
+	return fieldMap.Hooks.afterInsert(t, id)
+}
+
+func insertAndReadBack[T any](ex Executor, fieldMap *FieldMap, t *T, returnCols []string) error {
+	insertQuery, insertColumns := insertQueryFor(fieldMap, t)
+	args := argsForColumns(insertColumns, fieldMap, t)
+
+	if base, ok := strings.CutSuffix(insertQuery, returningIdSuffix); ok {
+		query := base + " RETURNING " + escapedColumnList(fieldMap.Driver, returnCols)
+		return debugged(ex).QueryRow(query, args...).Scan(*GetPointersForColumns(returnCols, fieldMap, t)...)
+	}
+
+	result, err := debugged(ex).Exec(insertQuery, args...)
+	if err != nil {
+		return err
+	}
+
+	if len(returnCols) == 0 {
+		return nil
+	}
+	idPos, ok := fieldMap.ColumnsMap["id"]
+	if !ok {
+		return errors.New("InsertReturning needs an id column to read back " + strings.Join(returnCols, ", "))
+	}
+	idField := fieldMap.field(reflect.ValueOf(t).Elem(), idPos)
+	if fieldMap.HasIntId {
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		idField.SetInt(id)
+	}
+
+	query := "SELECT " + escapedColumnList(fieldMap.Driver, returnCols) +
+		" FROM " + escapeTable(fieldMap.Driver, fieldMap.TableName) +
+		" WHERE " + escapeIdentifier(fieldMap.Driver, "id") + " = " + fieldMap.Driver.Placeholder(1)
+	return debugged(ex).QueryRow(query, idField.Interface()).Scan(*GetPointersForColumns(returnCols, fieldMap, t)...)
+}
+
+func escapedColumnList(driver Driver, columns []string) string {
+	escaped := make([]string, len(columns))
+	for i, column := range columns {
+		escaped[i] = escapeIdentifier(driver, column)
+	}
+	return strings.Join(escaped, ",")
+}
@@ -0,0 +1,77 @@
+package lit
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type TestToken struct {
+	Id        int
+	Email     string
+	Token     string    `lit:"token,readonly"`
+	CreatedAt time.Time `lit:"created_at,readonly"`
+}
+
+func TestInsertReturning_PostgreSQL(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestToken]())
+	RegisterModel[TestToken](PostgreSQL)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	createdAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`INSERT INTO test_tokens (id,email) VALUES (DEFAULT,$1) RETURNING id,token,created_at`).
+		WithArgs("alice@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "token", "created_at"}).AddRow(7, "abc123", createdAt))
+
+	token := &TestToken{Email: "alice@example.com"}
+	err = InsertReturning(db, token, []string{"token", "created_at"})
+	require.NoError(t, err)
+	assert.Equal(t, 7, token.Id)
+	assert.Equal(t, "abc123", token.Token)
+	assert.Equal(t, createdAt, token.CreatedAt)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertReturning_MySQL(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestToken]())
+	RegisterModel[TestToken](MySQL)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO test_tokens (id,email) VALUES (NULL,?)").
+		WithArgs("alice@example.com").
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectQuery("SELECT id,token FROM test_tokens WHERE id = ?").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "token"}).AddRow(7, "abc123"))
+
+	token := &TestToken{Email: "alice@example.com"}
+	err = InsertReturning(db, token, []string{"token"})
+	require.NoError(t, err)
+	assert.Equal(t, 7, token.Id)
+	assert.Equal(t, "abc123", token.Token)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertReturning_UnknownColumn(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestToken]())
+	RegisterModel[TestToken](PostgreSQL)
+
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	err = InsertReturning(db, &TestToken{Email: "alice@example.com"}, []string{"missing"})
+	assert.EqualError(t, err, "lit: TestToken.InsertReturning: invalid column that is not found in the struct: missing")
+}
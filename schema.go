@@ -0,0 +1,190 @@
+package lit
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ColumnProbeTTL is how long the result of an optional column probe is cached
+// before the table's columns are looked up again.
+var ColumnProbeTTL = time.Minute
+
+type columnProbe struct {
+	mu        sync.Mutex
+	checkedAt time.Time
+	missing   []string
+}
+
+// WithOptionalColumns marks columns that may not exist yet while a migration
+// is rolling out. SelectColumnList leaves missing optional columns out (so the
+// fields scan as zero values), and writes fail with a clear error until the
+// columns appear.
+func WithOptionalColumns(columns ...string) ModelOption {
+	return func(fieldMap *FieldMap) {
+		for _, column := range columns {
+			if !slices.Contains(fieldMap.ColumnKeys, column) {
+				panic("optional column is not found in the struct: " + column)
+			}
+		}
+		fieldMap.OptionalColumns = append(fieldMap.OptionalColumns, columns...)
+		fieldMap.optionalProbe = &columnProbe{}
+	}
+}
+
+// MissingOptionalColumns returns the optional columns that do not exist in the
+// model's table yet. Results are cached for ColumnProbeTTL.
+func (fieldMap *FieldMap) MissingOptionalColumns(ex Executor) ([]string, error) {
+	if len(fieldMap.OptionalColumns) == 0 {
+		return nil, nil
+	}
+
+	probe := fieldMap.optionalProbe
+	probe.mu.Lock()
+	defer probe.mu.Unlock()
+
+	if !probe.checkedAt.IsZero() && time.Since(probe.checkedAt) < ColumnProbeTTL {
+		return probe.missing, nil
+	}
+
+	existing, err := tableColumns(ex, fieldMap.Driver, fieldMap.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := []string{}
+	for _, column := range fieldMap.OptionalColumns {
+		if !slices.Contains(existing, column) {
+			missing = append(missing, column)
+		}
+	}
+
+	probe.missing = missing
+	probe.checkedAt = time.Now()
+	return missing, nil
+}
+
+// SelectColumnList returns the comma-separated column list for a SELECT of T,
+// leaving out optional columns that do not exist yet.
+func SelectColumnList[T any](ex Executor) (_ string, err error) {
+	defer wrapModelError[T]("SelectColumnList", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return "", err
+	}
+	return selectColumnList(ex, fieldMap)
+}
+
+func selectColumnList(ex Executor, fieldMap *FieldMap) (string, error) {
+	missing, err := fieldMap.MissingOptionalColumns(ex)
+	if err != nil {
+		return "", err
+	}
+
+	columns := []string{}
+	for _, column := range fieldMap.ColumnKeys {
+		if slices.Contains(missing, column) {
+			continue
+		}
+		columns = append(columns, escapeIdentifier(fieldMap.Driver, column))
+	}
+	return strings.Join(columns, ","), nil
+}
+
+func checkOptionalColumnsForWrite(ex Executor, fieldMap *FieldMap) error {
+	missing, err := fieldMap.MissingOptionalColumns(ex)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("cannot write optional column %s: it does not exist in table %s yet", missing[0], fieldMap.TableName)
+	}
+	return nil
+}
+
+// tableColumns lists the columns of tableName. A schema-qualified name such as
+// audit.audit_entries is looked up in that schema, an unqualified one in the
+// connection's current schema.
+func tableColumns(ex Executor, driver Driver, tableName string) ([]string, error) {
+	schema, table, qualified := strings.Cut(tableName, ".")
+	if !qualified {
+		schema, table = "", tableName
+	}
+
+	var query string
+	args := []any{table}
+	switch driver.(type) {
+	case *sqliteDriver:
+		query = "SELECT name FROM pragma_table_info(?)"
+		if qualified {
+			query = "SELECT name FROM pragma_table_info(?, ?)"
+			args = append(args, schema)
+		}
+	case *mysqlDriver:
+		query = "SELECT column_name FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ?"
+		if qualified {
+			query = "SELECT column_name FROM information_schema.columns WHERE table_schema = ? AND table_name = ?"
+			args = []any{schema, table}
+		}
+	case *pgDriver, *cockroachDriver:
+		query = "SELECT column_name FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = $1"
+		if qualified {
+			query = "SELECT column_name FROM information_schema.columns WHERE table_schema = $1 AND table_name = $2"
+			args = []any{schema, table}
+		}
+	default:
+		query = "SELECT column_name FROM information_schema.columns WHERE table_name = " + driver.Placeholder(1)
+		if qualified {
+			query = "SELECT column_name FROM information_schema.columns WHERE table_schema = " + driver.Placeholder(1) + " AND table_name = " + driver.Placeholder(2)
+			args = []any{schema, table}
+		}
+	}
+
+	rows, err := debugged(ex).Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := []string{}
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+func escapeIdentifier(driver Driver, name string) string {
+	switch d := driver.(type) {
+	case *pgDriver, *cockroachDriver:
+		return pgEscapeReserved(name)
+	case *mysqlDriver:
+		return mysqlEscapeReserved(name)
+	case *sqliteDriver:
+		return sqliteEscapeReserved(name)
+	case reservedEscaper:
+		return d.escapeReserved(name)
+	}
+	return name
+}
+
+// reservedEscaper is implemented by drivers compiled in behind a build tag,
+// which the switch in escapeIdentifier can't name.
+type reservedEscaper interface {
+	escapeReserved(name string) string
+}
+
+func escapeTable(driver Driver, tableName string) string {
+	return escapeTableName(tableName, func(name string) string {
+		return escapeIdentifier(driver, name)
+	})
+}
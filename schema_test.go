@@ -0,0 +1,113 @@
+package lit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type TestFlaggedUser struct {
+	Id      int
+	Name    string
+	NewFlag bool
+}
+
+func TestWithOptionalColumns_Registration(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestFlaggedUser]())
+	RegisterModelWithOptions[TestFlaggedUser](PostgreSQL, WithOptionalColumns("new_flag"))
+
+	fieldMap, err := GetFieldMap(reflect.TypeFor[TestFlaggedUser]())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"new_flag"}, fieldMap.OptionalColumns)
+	assert.Equal(t, "test_flagged_users", fieldMap.TableName)
+
+	assert.Panics(t, func() {
+		RegisterModelWithOptions[TestFlaggedUser](PostgreSQL, WithOptionalColumns("unknown"))
+	})
+}
+
+func TestOptionalColumns_BeforeAndAfterMigration(t *testing.T) {
+	originalTTL := ColumnProbeTTL
+	defer func() { ColumnProbeTTL = originalTTL }()
+	ColumnProbeTTL = 0
+
+	delete(StructToFieldMap, reflect.TypeFor[TestFlaggedUser]())
+	RegisterModelWithOptions[TestFlaggedUser](PostgreSQL, WithOptionalColumns("new_flag"))
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	probe := "SELECT column_name FROM information_schema.columns WHERE table_schema = current_schema\\(\\) AND table_name = \\$1"
+
+	// Before the migration: new_flag is skipped on reads and rejected on writes.
+	mock.ExpectQuery(probe).WithArgs("test_flagged_users").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name"}).AddRow("id").AddRow("name"))
+
+	columns, err := SelectColumnList[TestFlaggedUser](db)
+	require.NoError(t, err)
+	assert.Equal(t, `id,"name"`, columns)
+
+	mock.ExpectQuery(probe).WithArgs("test_flagged_users").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name"}).AddRow("id").AddRow("name"))
+	mock.ExpectQuery("SELECT id,\"name\" FROM test_flagged_users").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John"))
+
+	columns, err = SelectColumnList[TestFlaggedUser](db)
+	require.NoError(t, err)
+	users, err := Select[TestFlaggedUser](db, "SELECT "+columns+" FROM test_flagged_users")
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.False(t, users[0].NewFlag)
+
+	mock.ExpectQuery(probe).WithArgs("test_flagged_users").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name"}).AddRow("id").AddRow("name"))
+
+	_, err = Insert(db, &TestFlaggedUser{Name: "John"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "new_flag")
+
+	// After the migration: the column shows up and everything flips automatically.
+	mock.ExpectQuery(probe).WithArgs("test_flagged_users").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name"}).AddRow("id").AddRow("name").AddRow("new_flag"))
+
+	columns, err = SelectColumnList[TestFlaggedUser](db)
+	require.NoError(t, err)
+	assert.Equal(t, `id,"name",new_flag`, columns)
+
+	mock.ExpectQuery(probe).WithArgs("test_flagged_users").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name"}).AddRow("id").AddRow("name").AddRow("new_flag"))
+	mock.ExpectQuery("INSERT INTO test_flagged_users").
+		WithArgs("John", true).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	id, err := Insert(db, &TestFlaggedUser{Name: "John", NewFlag: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, id)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOptionalColumns_ProbeIsCached_SQLite(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestFlaggedUser]())
+	RegisterModelWithOptions[TestFlaggedUser](SQLite, WithOptionalColumns("new_flag"))
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT name FROM pragma_table_info\\(\\?\\)").WithArgs("test_flagged_users").
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("id").AddRow("name"))
+
+	err = Update(db, &TestFlaggedUser{Id: 1}, "id = ?", 1)
+	require.Error(t, err)
+
+	err = Update(db, &TestFlaggedUser{Id: 1}, "id = ?", 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not exist in table test_flagged_users yet")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
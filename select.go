@@ -0,0 +1,692 @@
+package lit
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+type selectConfig struct {
+	orderBy   string
+	unordered bool
+}
+
+// SelectOption tunes the queries generated by SelectAll and friends.
+type SelectOption func(*selectConfig)
+
+// OrderBy overrides the model's default order. Terms use the same
+// "column.direction" syntax as WithDefaultOrder, e.g. "name.asc, id.desc".
+func OrderBy(order string) SelectOption {
+	return func(c *selectConfig) {
+		c.orderBy = order
+	}
+}
+
+// Unordered suppresses the model's default order.
+func Unordered() SelectOption {
+	return func(c *selectConfig) {
+		c.unordered = true
+	}
+}
+
+// WithDefaultOrder sets the ORDER BY used when the caller gives none, e.g.
+// "created_at.desc, id.desc". It applies to generated selects without an
+// explicit OrderBy (SelectAll, QueryBuilder), to SelectPaged and SelectMapsFor
+// queries without their own ORDER BY, and to the chunk order of SelectChunked.
+func WithDefaultOrder(order string) ModelOption {
+	return func(fieldMap *FieldMap) {
+		terms, err := parseOrderTerms(fieldMap, order)
+		if err != nil {
+			panic(err.Error())
+		}
+		fieldMap.DefaultOrder = orderClause(fieldMap.Driver, terms)
+		fieldMap.defaultOrder = terms
+	}
+}
+
+// SelectAll loads every row of T's table, ordered by the model's default order
+// unless overridden.
+func SelectAll[T any](ex Executor, opts ...SelectOption) (_ []*T, err error) {
+	defer wrapModelError[T]("SelectAll", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	query, err := buildSelectQuery(ex, fieldMap, "", opts)
+	if err != nil {
+		return nil, err
+	}
+	return Select[T](ex, query)
+}
+
+// SelectById loads the row of T with the given id, or returns nil, nil when
+// there is none.
+func SelectById[T any](ex Executor, id any) (_ *T, err error) {
+	defer wrapModelError[T]("SelectById", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := fieldMap.ColumnsMap["id"]; !ok {
+		return nil, fmt.Errorf("model %s has no id column", reflect.TypeFor[T]().Name())
+	}
+	where := escapeIdentifier(fieldMap.Driver, "id") + " = " + fieldMap.Driver.Placeholder(1)
+	query, err := buildSelectQuery(ex, fieldMap, where, []SelectOption{Unordered()})
+	if err != nil {
+		return nil, err
+	}
+	return SelectSingle[T](ex, query, id)
+}
+
+// SelectByIDs loads the rows of T with the given integer ids, see
+// SelectByIDsTyped.
+func SelectByIDs[T any](ex Executor, ids []int) (_ []*T, err error) {
+	defer wrapModelError[T]("SelectByIDs", &err)
+	return SelectByIDsTyped[T](ex, ids)
+}
+
+// SelectByIDsTyped loads the rows of T whose id is in ids, in no particular
+// order. Large id lists are split into several queries whose results are
+// merged; an empty list returns an empty result without a query.
+func SelectByIDsTyped[T any, ID int | int64 | string](ex Executor, ids []ID) (_ []*T, err error) {
+	defer wrapModelError[T]("SelectByIDsTyped", &err)
+	if len(ids) == 0 {
+		return []*T{}, nil
+	}
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := fieldMap.ColumnsMap["id"]; !ok {
+		return nil, fmt.Errorf("model %s has no id column", reflect.TypeFor[T]().Name())
+	}
+
+	list := make([]*T, 0, len(ids))
+	for chunk := range slices.Chunk(ids, byIdsChunkSize) {
+		args := make([]any, len(chunk))
+		for i, id := range chunk {
+			args[i] = id
+		}
+		where := escapeIdentifier(fieldMap.Driver, "id") + " IN (" + fieldMap.Driver.JoinStringForIn(0, len(chunk)) + ")"
+		query, err := buildSelectQuery(ex, fieldMap, where, []SelectOption{Unordered()})
+		if err != nil {
+			return nil, err
+		}
+		items, err := Select[T](ex, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, items...)
+	}
+	return list, nil
+}
+
+// Count returns the number of rows of T matching where, or of the whole table
+// when where is empty. where may start with the WHERE keyword and numbers its
+// placeholders from 1.
+func Count[T any](ex Executor, where string, args ...any) (_ int64, err error) {
+	defer wrapModelError[T]("Count", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return 0, err
+	}
+
+	query := "SELECT COUNT(*) FROM " + escapeTable(fieldMap.Driver, fieldMap.TableName)
+	where = trimWhereKeyword(where)
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := debugged(ex).QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// SelectValue runs a query and scans the first column of its first row into V,
+// e.g. int64, string, time.Time or sql.NullTime. It returns ErrNotFound when
+// the query returns no rows. Aggregates like MAX return a NULL row on empty
+// tables, so scan those into a sql.Null* type.
+func SelectValue[V any](ex Executor, query string, args ...any) (V, error) {
+	var v V
+	rows, err := debugged(ex).Query(query, args...)
+	if err != nil {
+		return v, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return v, err
+		}
+		return v, ErrNotFound
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return v, err
+	}
+	dest := make([]any, len(columns))
+	dest[0] = &v
+	for i := 1; i < len(dest); i++ {
+		dest[i] = new(any)
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return v, err
+	}
+	return v, rows.Err()
+}
+
+// ScalarQuery is SelectValue for a query belonging to the registered model T,
+// e.g. ScalarQuery[Order, float64](db, "SELECT MAX(total) FROM orders").
+func ScalarQuery[T any, R any](ex Executor, query string, args ...any) (_ R, err error) {
+	defer wrapModelError[T]("ScalarQuery", &err)
+	if _, err := GetFieldMap(reflect.TypeFor[T]()); err != nil {
+		var zero R
+		return zero, err
+	}
+	return SelectValue[R](ex, query, args...)
+}
+
+// SelectColumn runs a query returning exactly one column and scans it into a
+// slice of C. M is the registered model the query belongs to; use
+// SelectValues when there is none.
+func SelectColumn[M any, C any](ex Executor, query string, args ...any) (_ []C, err error) {
+	defer wrapModelError[M]("SelectColumn", &err)
+	if _, err := GetFieldMap(reflect.TypeFor[M]()); err != nil {
+		return nil, err
+	}
+	return selectValues[C]("SelectColumn", ex, query, args)
+}
+
+// SelectValues runs a query returning exactly one column and scans every row
+// into a slice of V. Use a pointer V, e.g. *string, for nullable columns.
+func SelectValues[V any](ex Executor, query string, args ...any) ([]V, error) {
+	return selectValues[V]("SelectValues", ex, query, args)
+}
+
+func selectValues[V any](caller string, ex Executor, query string, args []any) ([]V, error) {
+	rows, err := debugged(ex).Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) != 1 {
+		return nil, fmt.Errorf("%s expects a single column, query returned %d", caller, len(columns))
+	}
+
+	values := []V{}
+	for rows.Next() {
+		var v V
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// SelectMaps runs an ad-hoc query without a model and returns one map per row,
+// keyed by column name. Byte slices returned by the driver are converted to
+// strings.
+func SelectMaps(ex Executor, query string, args ...any) ([]map[string]any, error) {
+	rows, err := debugged(ex).Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	list := []map[string]any{}
+	values := make([]any, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		row := make(map[string]any, len(columns))
+		for i, column := range columns {
+			if b, ok := values[i].([]byte); ok {
+				row[column] = string(b)
+			} else {
+				row[column] = values[i]
+			}
+		}
+		list = append(list, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// SelectMapsFor is SelectMaps for a query on T's table, ordered by T's
+// default order when query has no ORDER BY of its own.
+func SelectMapsFor[T any](ex Executor, query string, args ...any) (_ []map[string]any, err error) {
+	defer wrapModelError[T]("SelectMapsFor", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	return SelectMaps(ex, appendDefaultOrder(fieldMap, query), args...)
+}
+
+// pagingClause appends limit and offset to args in the order the driver binds
+// them and returns the clause paging by them. A negative limit or offset
+// leaves that part out.
+func pagingClause(driver Driver, args []any, limit int, offset int) (string, []any) {
+	if p, ok := driver.(pager); ok {
+		return p.pagingClause(args, limit, offset)
+	}
+	clause := ""
+	if limit >= 0 {
+		args = append(args, limit)
+		clause += " LIMIT " + driver.Placeholder(len(args))
+	}
+	if offset >= 0 {
+		args = append(args, offset)
+		clause += " OFFSET " + driver.Placeholder(len(args))
+	}
+	return clause, args
+}
+
+// pager is implemented by drivers compiled in behind a build tag whose paging
+// syntax isn't LIMIT/OFFSET.
+type pager interface {
+	pagingClause(args []any, limit int, offset int) (string, []any)
+}
+
+// SelectPaged returns page (starting at 1) of query's rows, perPage at a time,
+// together with the total number of rows query matches. query is run once
+// with the driver's LIMIT and OFFSET appended and once wrapped in a COUNT(*) with its
+// trailing ORDER BY removed. Without an ORDER BY, pages follow T's default
+// order.
+func SelectPaged[T any](ex Executor, query string, page int, perPage int, args ...any) (_ []*T, _ int64, err error) {
+	defer wrapModelError[T]("SelectPaged", &err)
+	if page < 1 {
+		return nil, 0, fmt.Errorf("page must be at least 1, got %d", page)
+	}
+	if perPage < 1 {
+		return nil, 0, fmt.Errorf("perPage must be at least 1, got %d", perPage)
+	}
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query = strings.TrimRight(strings.TrimSpace(query), ";")
+	countQuery := "SELECT COUNT(*) FROM (" + stripOrderBy(query) + ") lit_paged"
+	var total int64
+	if err := debugged(ex).QueryRow(countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	paging, pageArgs := pagingClause(fieldMap.Driver, slices.Clone(args), perPage, (page-1)*perPage)
+	pageQuery := appendDefaultOrder(fieldMap, query) + paging
+	items, err := Select[T](ex, pageQuery, pageArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// SelectChunked walks the rows of T matching where in the model's default
+// order followed by id (or just id order), chunkSize at a time, calling fn with
+// each chunk. Chunks after the first are selected with a keyset condition on
+// those columns, e.g. id > the last id seen, so rows are never skipped or
+// repeated while the table is written to. Default order columns must not be
+// NULL. It stops after a short chunk, when fn returns an error, or cleanly
+// when fn returns Stop. where is as for Count.
+func SelectChunked[T any](ex Executor, where string, chunkSize int, fn func([]*T) error, args ...any) (err error) {
+	defer wrapModelError[T]("SelectChunked", &err)
+	if chunkSize < 1 {
+		return fmt.Errorf("chunkSize must be at least 1, got %d", chunkSize)
+	}
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return err
+	}
+	if _, ok := fieldMap.ColumnsMap["id"]; !ok {
+		return fmt.Errorf("model %s has no id column", reflect.TypeFor[T]().Name())
+	}
+
+	driver := fieldMap.Driver
+	keys := chunkKeys(fieldMap)
+	order := make([]string, len(keys))
+	for i, key := range keys {
+		order[i] = key.column + ".asc"
+		if key.desc {
+			order[i] = key.column + ".desc"
+		}
+	}
+	where = trimWhereKeyword(where)
+	if where != "" {
+		where = "(" + where + ")"
+	}
+
+	var last []any
+	for {
+		chunkWhere, chunkArgs := where, slices.Clone(args)
+		if last != nil {
+			if chunkWhere != "" {
+				chunkWhere += " AND "
+			}
+			condition, keyArgs := keysetCondition(driver, keys, last, len(chunkArgs))
+			chunkWhere += condition
+			chunkArgs = append(chunkArgs, keyArgs...)
+		}
+		query, err := buildSelectQuery(ex, fieldMap, chunkWhere, []SelectOption{OrderBy(strings.Join(order, ","))})
+		if err != nil {
+			return err
+		}
+		paging, chunkArgs := pagingClause(driver, chunkArgs, chunkSize, -1)
+		query += paging
+
+		chunk, err := Select[T](ex, query, chunkArgs...)
+		if err != nil {
+			return err
+		}
+		if len(chunk) == 0 {
+			return nil
+		}
+		if err := fn(chunk); err != nil {
+			if errors.Is(err, Stop) {
+				return nil
+			}
+			return err
+		}
+		if len(chunk) < chunkSize {
+			return nil
+		}
+		row := reflect.ValueOf(chunk[len(chunk)-1]).Elem()
+		last = make([]any, len(keys))
+		for i, key := range keys {
+			last[i] = fieldMap.field(row, fieldMap.ColumnsMap[key.column]).Interface()
+		}
+	}
+}
+
+// chunkKeys returns the SelectChunked order: the default order up to id, then
+// id ascending when the default order doesn't include it.
+func chunkKeys(fieldMap *FieldMap) []orderTerm {
+	keys := []orderTerm{}
+	for _, term := range fieldMap.defaultOrder {
+		keys = append(keys, term)
+		if term.column == "id" {
+			return keys
+		}
+	}
+	return append(keys, orderTerm{column: "id"})
+}
+
+// keysetCondition selects the rows after the key values last in the order of
+// keys, e.g. "(created_at < $1 OR (created_at = $2 AND id > $3))". Placeholders
+// are numbered after argCount.
+func keysetCondition(driver Driver, keys []orderTerm, last []any, argCount int) (string, []any) {
+	args := []any{}
+	alternatives := make([]string, len(keys))
+	for i, key := range keys {
+		conds := make([]string, 0, i+1)
+		for j := range i {
+			args = append(args, last[j])
+			conds = append(conds, escapeIdentifier(driver, keys[j].column)+" = "+driver.Placeholder(argCount+len(args)))
+		}
+		op := " > "
+		if key.desc {
+			op = " < "
+		}
+		args = append(args, last[i])
+		conds = append(conds, escapeIdentifier(driver, key.column)+op+driver.Placeholder(argCount+len(args)))
+		alternatives[i] = strings.Join(conds, " AND ")
+		if len(conds) > 1 {
+			alternatives[i] = "(" + alternatives[i] + ")"
+		}
+	}
+	if len(alternatives) == 1 {
+		return alternatives[0], args
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")", args
+}
+
+// trimWhereKeyword trims where and a leading WHERE keyword from it.
+func trimWhereKeyword(where string) string {
+	where = strings.TrimSpace(where)
+	if len(where) >= 6 && strings.EqualFold(where[:6], "WHERE ") {
+		where = strings.TrimSpace(where[6:])
+	}
+	return where
+}
+
+// stripOrderBy removes a trailing top-level ORDER BY clause from query.
+func stripOrderBy(query string) string {
+	upper := strings.ToUpper(query)
+	depth := 0
+	inString := false
+	cut := -1
+	for i := 0; i < len(upper); i++ {
+		switch c := upper[i]; {
+		case c == '\'':
+			inString = !inString
+		case inString:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && strings.HasPrefix(upper[i:], "ORDER BY") && (i == 0 || !isParamChar(rune(upper[i-1]))):
+			cut = i
+		}
+	}
+	if cut < 0 {
+		return query
+	}
+	return strings.TrimSpace(query[:cut])
+}
+
+// SelectForUpdate runs query with FOR UPDATE appended, locking the selected
+// rows until the surrounding transaction ends.
+func SelectForUpdate[T any](ex Executor, query string, args ...any) (_ []*T, err error) {
+	defer wrapModelError[T]("SelectForUpdate", &err)
+	return selectWithLock[T](ex, query, "FOR UPDATE", args)
+}
+
+// SelectForShare runs query with the driver's shared lock clause appended
+// (FOR SHARE, or LOCK IN SHARE MODE on MySQL).
+func SelectForShare[T any](ex Executor, query string, args ...any) (_ []*T, err error) {
+	defer wrapModelError[T]("SelectForShare", &err)
+	return selectWithLock[T](ex, query, "", args)
+}
+
+func selectWithLock[T any](ex Executor, query string, clause string, args []any) ([]*T, error) {
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	shareClause := fieldMap.Driver.ForShareClause()
+	if shareClause == "" {
+		return nil, ErrUnsupportedOperation
+	}
+	if clause == "" {
+		clause = shareClause
+	}
+	query = strings.TrimRight(query, "; \t\n") + " " + clause
+	return Select[T](ex, query, args...)
+}
+
+// InMarker is the placeholder SelectIn replaces with the IN list.
+const InMarker = "{in}"
+
+// SelectIn runs query with its single {in} marker expanded to one placeholder
+// per id, e.g. "SELECT * FROM users WHERE id IN ({in}) AND active = $1".
+// An empty ids slice returns an empty result without querying.
+func SelectIn[T any, ID any](ex Executor, query string, ids []ID, args ...any) (_ []*T, err error) {
+	defer wrapModelError[T]("SelectIn", &err)
+	if len(ids) == 0 {
+		return []*T{}, nil
+	}
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	expanded, expandedArgs, err := expandInMarker(fieldMap.Driver, query, ids, args)
+	if err != nil {
+		return nil, err
+	}
+	return Select[T](ex, expanded, expandedArgs...)
+}
+
+func expandInMarker[ID any](driver Driver, query string, ids []ID, args []any) (string, []any, error) {
+	// Markers and placeholders inside literals or comments don't count.
+	runes := []rune(query)
+	marker := []rune(InMarker)
+	markers := []int{}
+	newSQLLexer(driver).code(runes, func(i int) {
+		if hasRunesAt(runes, i, marker) {
+			markers = append(markers, i)
+		}
+	})
+	if len(markers) != 1 {
+		return "", nil, fmt.Errorf("query must contain exactly one %s marker", InMarker)
+	}
+	markerAt := markers[0]
+
+	idArgs := make([]any, len(ids))
+	for i, id := range ids {
+		idArgs[i] = id
+	}
+
+	// Numbered placeholders ($1, $2) can reference args in any order, so the ids
+	// go last. Positional ones (?) must follow the placeholders before the marker.
+	insertAt := len(args)
+	if driver.Placeholder(1) == driver.Placeholder(2) {
+		before := 0
+		for _, p := range scanPlaceholders(driver, query) {
+			if p.at < markerAt {
+				before++
+			}
+		}
+		insertAt = min(before, len(args))
+	}
+
+	expandedArgs := make([]any, 0, len(args)+len(ids))
+	expandedArgs = append(expandedArgs, args[:insertAt]...)
+	expandedArgs = append(expandedArgs, idArgs...)
+	expandedArgs = append(expandedArgs, args[insertAt:]...)
+
+	placeholders := driver.JoinStringForIn(len(args), len(ids))
+	return string(runes[:markerAt]) + placeholders + string(runes[markerAt+len(marker):]), expandedArgs, nil
+}
+
+// buildSelectQuery generates SELECT <columns> FROM <table> [WHERE <where>] [ORDER BY ...].
+func buildSelectQuery(ex Executor, fieldMap *FieldMap, where string, opts []SelectOption) (string, error) {
+	config := selectConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	columns, err := selectColumnList(ex, fieldMap)
+	if err != nil {
+		return "", err
+	}
+
+	var query strings.Builder
+	query.WriteString("SELECT ")
+	query.WriteString(columns)
+	query.WriteString(" FROM ")
+	query.WriteString(escapeTable(fieldMap.Driver, fieldMap.TableName))
+	if where != "" {
+		query.WriteString(" WHERE ")
+		query.WriteString(where)
+	}
+
+	orderClause := fieldMap.DefaultOrder
+	if config.orderBy != "" {
+		orderClause, err = buildOrderClause(fieldMap, config.orderBy)
+		if err != nil {
+			return "", err
+		}
+	}
+	if orderClause != "" && !config.unordered {
+		query.WriteString(" ORDER BY ")
+		query.WriteString(orderClause)
+	}
+
+	return query.String(), nil
+}
+
+// appendDefaultOrder appends the model's default order to a hand-written query
+// that has no top-level ORDER BY of its own.
+func appendDefaultOrder(fieldMap *FieldMap, query string) string {
+	if fieldMap.DefaultOrder == "" || stripOrderBy(query) != query {
+		return query
+	}
+	return query + " ORDER BY " + fieldMap.DefaultOrder
+}
+
+// orderTerm is one "column.direction" term of an order.
+type orderTerm struct {
+	column string
+	desc   bool
+}
+
+// buildOrderClause turns "created_at.desc, id" into an escaped
+// "created_at DESC,id ASC", validating every column against the model.
+func buildOrderClause(fieldMap *FieldMap, order string) (string, error) {
+	terms, err := parseOrderTerms(fieldMap, order)
+	if err != nil {
+		return "", err
+	}
+	return orderClause(fieldMap.Driver, terms), nil
+}
+
+func parseOrderTerms(fieldMap *FieldMap, order string) ([]orderTerm, error) {
+	terms := []orderTerm{}
+	for _, term := range strings.Split(order, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		column, direction := term, "asc"
+		if dot := strings.LastIndex(term, "."); dot >= 0 {
+			column, direction = term[:dot], strings.ToLower(term[dot+1:])
+		}
+		if !slices.Contains(fieldMap.ColumnKeys, column) {
+			return nil, fmt.Errorf("invalid order column that is not found in the struct: %s", column)
+		}
+		if direction != "asc" && direction != "desc" {
+			return nil, fmt.Errorf("invalid order direction %q for column %s", direction, column)
+		}
+
+		terms = append(terms, orderTerm{column: column, desc: direction == "desc"})
+	}
+	return terms, nil
+}
+
+func orderClause(driver Driver, terms []orderTerm) string {
+	clauses := make([]string, len(terms))
+	for i, term := range terms {
+		clauses[i] = escapeIdentifier(driver, term.column) + " ASC"
+		if term.desc {
+			clauses[i] = escapeIdentifier(driver, term.column) + " DESC"
+		}
+	}
+	return strings.Join(clauses, ",")
+}
@@ -0,0 +1,764 @@
+package lit
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type TestOrderedPost struct {
+	Id        int
+	Title     string
+	CreatedAt int
+	Order     int
+}
+
+func TestWithDefaultOrder_Registration(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestOrderedPost]())
+	RegisterModelWithOptions[TestOrderedPost](PostgreSQL, WithDefaultOrder("created_at.desc, id.desc"))
+
+	fieldMap, err := GetFieldMap(reflect.TypeFor[TestOrderedPost]())
+	require.NoError(t, err)
+	assert.Equal(t, "created_at DESC,id DESC", fieldMap.DefaultOrder)
+
+	assert.Panics(t, func() {
+		RegisterModelWithOptions[TestOrderedPost](PostgreSQL, WithDefaultOrder("missing.desc"))
+	})
+	assert.Panics(t, func() {
+		RegisterModelWithOptions[TestOrderedPost](PostgreSQL, WithDefaultOrder("id.sideways"))
+	})
+}
+
+func TestWithDefaultOrder_EscapesPerDriver(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestOrderedPost]())
+	RegisterModelWithOptions[TestOrderedPost](MySQL, WithDefaultOrder("order, id.desc"))
+
+	fieldMap, err := GetFieldMap(reflect.TypeFor[TestOrderedPost]())
+	require.NoError(t, err)
+	assert.Equal(t, "`order` ASC,id DESC", fieldMap.DefaultOrder)
+
+	delete(StructToFieldMap, reflect.TypeFor[TestOrderedPost]())
+	RegisterModelWithOptions[TestOrderedPost](PostgreSQL, WithDefaultOrder("order, id.desc"))
+
+	fieldMap, err = GetFieldMap(reflect.TypeFor[TestOrderedPost]())
+	require.NoError(t, err)
+	assert.Equal(t, `"order" ASC,id DESC`, fieldMap.DefaultOrder)
+}
+
+func TestSelectAll_DefaultOrder(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestOrderedPost]())
+	RegisterModelWithOptions[TestOrderedPost](PostgreSQL, WithDefaultOrder("created_at.desc, id.desc"))
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT id,title,created_at,"order" FROM test_ordered_posts ORDER BY created_at DESC,id DESC`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "created_at", "order"}).AddRow(2, "b", 20, 0).AddRow(1, "a", 10, 0))
+
+	posts, err := SelectAll[TestOrderedPost](db)
+	require.NoError(t, err)
+	assert.Len(t, posts, 2)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectAll_ExplicitOrderBy(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestOrderedPost]())
+	RegisterModelWithOptions[TestOrderedPost](PostgreSQL, WithDefaultOrder("created_at.desc"))
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT id,title,created_at,"order" FROM test_ordered_posts ORDER BY title ASC`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err = SelectAll[TestOrderedPost](db, OrderBy("title.asc"))
+	require.NoError(t, err)
+
+	_, err = SelectAll[TestOrderedPost](db, OrderBy("nope"))
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectAll_Unordered(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestOrderedPost]())
+	RegisterModelWithOptions[TestOrderedPost](SQLite, WithDefaultOrder("created_at.desc"))
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT id,title,created_at,"order" FROM test_ordered_posts`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err = SelectAll[TestOrderedPost](db, Unordered())
+	require.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectIn_PostgreSQL(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT * FROM test_users WHERE last_name = $1 AND id IN ($2,$3,$4)").
+		WithArgs("Doe", 1, 2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "last_name"}).AddRow(1, "Doe").AddRow(3, "Doe"))
+
+	users, err := SelectIn[TestUser](db, "SELECT * FROM test_users WHERE last_name = $1 AND id IN ({in})", []int{1, 2, 3}, "Doe")
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectIn_MySQL(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](MySQL)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT * FROM test_users WHERE last_name = ? AND id IN (?,?) AND email <> ?").
+		WithArgs("Doe", 1, 2, "x").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	users, err := SelectIn[TestUser](db, "SELECT * FROM test_users WHERE last_name = ? AND id IN ({in}) AND email <> ?", []int{1, 2}, "Doe", "x")
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectIn_SkipsLiterals(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](MySQL)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT * FROM test_users WHERE last_name <> 'who? {in}' AND email = ? /* ? */ AND id IN (?,?)").
+		WithArgs("x", 1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	users, err := SelectIn[TestUser](db, "SELECT * FROM test_users WHERE last_name <> 'who? {in}' AND email = ? /* ? */ AND id IN ({in})", []int{1, 2}, "x")
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectIn_EmptyIds(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](SQLite)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	users, err := SelectIn[TestUser](db, "SELECT * FROM test_users WHERE id IN ({in})", []string{})
+	require.NoError(t, err)
+	assert.NotNil(t, users)
+	assert.Empty(t, users)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectIn_MissingMarker(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](SQLite)
+
+	_, err := SelectIn[TestUser](nil, "SELECT * FROM test_users WHERE id IN (?)", []int{1})
+	assert.Error(t, err)
+}
+
+func TestSelectForUpdate_PostgreSQL(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT * FROM test_users WHERE id = $1 FOR UPDATE").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("SELECT * FROM test_users WHERE id = $1 FOR SHARE").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	users, err := SelectForUpdate[TestUser](db, "SELECT * FROM test_users WHERE id = $1;", 1)
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+
+	users, err = SelectForShare[TestUser](db, "SELECT * FROM test_users WHERE id = $1", 1)
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectForShare_MySQL(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](MySQL)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT * FROM test_users WHERE id = ? LOCK IN SHARE MODE").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	_, err = SelectForShare[TestUser](db, "SELECT * FROM test_users WHERE id = ?", 1)
+	require.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectWithLock_SQLiteUnsupported(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](SQLite)
+
+	_, err := SelectForUpdate[TestUser](nil, "SELECT * FROM test_users")
+	assert.ErrorIs(t, err, ErrUnsupportedOperation)
+
+	_, err = SelectForShare[TestUser](nil, "SELECT * FROM test_users")
+	assert.ErrorIs(t, err, ErrUnsupportedOperation)
+}
+
+func TestSelectById(t *testing.T) {
+	for _, tc := range []struct {
+		driver Driver
+		query  string
+	}{
+		{PostgreSQL, "SELECT id,first_name,last_name,email FROM test_users WHERE id = $1"},
+		{MySQL, "SELECT id,first_name,last_name,email FROM test_users WHERE id = ?"},
+		{SQLite, "SELECT id,first_name,last_name,email FROM test_users WHERE id = ?"},
+	} {
+		t.Run(tc.driver.Name(), func(t *testing.T) {
+			delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+			RegisterModelWithOptions[TestUser](tc.driver, WithDefaultOrder("id.desc"))
+			defer delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectQuery(tc.query).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "email"}).
+					AddRow(1, "John", "Doe", "john@example.com"))
+			mock.ExpectQuery(tc.query).
+				WithArgs(2).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "email"}))
+
+			user, err := SelectById[TestUser](db, 1)
+			require.NoError(t, err)
+			assert.Equal(t, &TestUser{Id: 1, FirstName: "John", LastName: "Doe", Email: "john@example.com"}, user)
+
+			missing, err := SelectById[TestUser](db, 2)
+			require.NoError(t, err)
+			assert.Nil(t, missing)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSelectByIDs(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id,first_name,last_name,email FROM test_users WHERE id IN ($1,$2)").
+		WithArgs(1, 4).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "email"}).
+			AddRow(1, "John", "Doe", "").AddRow(4, "Jane", "Doe", ""))
+
+	users, err := SelectByIDs[TestUser](db, []int{1, 4})
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "Jane", users[1].FirstName)
+
+	users, err = SelectByIDs[TestUser](db, nil)
+	require.NoError(t, err)
+	assert.Empty(t, users)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectByIDs_Chunks(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](SQLite)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ids := make([]int64, byIdsChunkSize+1)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+	columns := []string{"id", "first_name", "last_name", "email"}
+	mock.ExpectQuery("SELECT id,first_name,last_name,email FROM test_users WHERE id IN").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "John", "Doe", ""))
+	mock.ExpectQuery(`SELECT id,first_name,last_name,email FROM test_users WHERE id IN \(\?\)`).
+		WithArgs(int64(byIdsChunkSize + 1)).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(byIdsChunkSize+1, "Jane", "Doe", ""))
+
+	users, err := SelectByIDsTyped[TestUser](db, ids)
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectColumn(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT email FROM test_users").
+		WillReturnRows(sqlmock.NewRows([]string{"email"}).AddRow("a@example.com").AddRow("b@example.com"))
+	mock.ExpectQuery("SELECT id FROM test_users").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery("SELECT id, email FROM test_users").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(1, "a@example.com"))
+	mock.ExpectQuery("SELECT email FROM test_users").
+		WillReturnRows(sqlmock.NewRows([]string{"email"}).AddRow("not a number"))
+
+	emails, err := SelectColumn[TestUser, string](db, "SELECT email FROM test_users")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a@example.com", "b@example.com"}, emails)
+
+	ids, err := SelectColumn[TestUser, int](db, "SELECT id FROM test_users")
+	require.NoError(t, err)
+	assert.Equal(t, []int{}, ids)
+
+	_, err = SelectColumn[TestUser, int](db, "SELECT id, email FROM test_users")
+	assert.EqualError(t, err, "lit: TestUser.SelectColumn: SelectColumn expects a single column, query returned 2")
+
+	_, err = SelectColumn[TestUser, int](db, "SELECT email FROM test_users")
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCount(t *testing.T) {
+	for _, tc := range []struct {
+		driver Driver
+		where  string
+		query  string
+	}{
+		{PostgreSQL, "first_name = $1 AND last_name = $2", "SELECT COUNT(*) FROM test_users WHERE first_name = $1 AND last_name = $2"},
+		{MySQL, "WHERE first_name = ? AND last_name = ?", "SELECT COUNT(*) FROM test_users WHERE first_name = ? AND last_name = ?"},
+		{SQLite, "first_name = ? AND last_name = ?", "SELECT COUNT(*) FROM test_users WHERE first_name = ? AND last_name = ?"},
+	} {
+		t.Run(tc.driver.Name(), func(t *testing.T) {
+			delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+			RegisterModel[TestUser](tc.driver)
+
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectQuery("SELECT COUNT(*) FROM test_users").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+			mock.ExpectQuery(tc.query).WithArgs("John", "Doe").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			mock.ExpectQuery(tc.query).WithArgs("Jane", "Doe").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+			count, err := Count[TestUser](db, "")
+			require.NoError(t, err)
+			assert.Equal(t, int64(12), count)
+
+			count, err = Count[TestUser](db, tc.where, "John", "Doe")
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), count)
+
+			count, err = CountNamed[TestUser](db, "first_name = :first AND last_name = :last", P{"first": "Jane", "last": "Doe"})
+			require.NoError(t, err)
+			assert.Equal(t, int64(0), count)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCount_NotRegistered(t *testing.T) {
+	type UnregisteredType struct {
+		Id int
+	}
+
+	_, err := Count[UnregisteredType](nil, "")
+	assert.ErrorAs(t, err, &NotRegisteredError{})
+}
+
+func TestSelectValue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT count").
+		WillReturnRows(sqlmock.NewRows([]string{"count", "team_id"}).AddRow(7, 1).AddRow(3, 2))
+	mock.ExpectQuery("SELECT max\\(created_at\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(createdAt))
+	mock.ExpectQuery("SELECT max\\(created_at\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(nil))
+	mock.ExpectQuery("SELECT value FROM settings WHERE name = \\$1").
+		WithArgs("theme").
+		WillReturnRows(sqlmock.NewRows([]string{"value"}).AddRow("dark"))
+	mock.ExpectQuery("SELECT value FROM settings WHERE name = \\$1").
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows([]string{"value"}))
+
+	count, err := SelectValue[int64](db, "SELECT count(*), team_id FROM users GROUP BY team_id")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), count)
+
+	latest, err := SelectValue[time.Time](db, "SELECT max(created_at) FROM users")
+	require.NoError(t, err)
+	assert.Equal(t, createdAt, latest)
+
+	nullable, err := SelectValue[sql.NullTime](db, "SELECT max(created_at) FROM users")
+	require.NoError(t, err)
+	assert.False(t, nullable.Valid)
+
+	theme, err := SelectValueNamed[string](PostgreSQL, db, "SELECT value FROM settings WHERE name = :name", P{"name": "theme"})
+	require.NoError(t, err)
+	assert.Equal(t, "dark", theme)
+
+	_, err = SelectValue[string](db, "SELECT value FROM settings WHERE name = $1", "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectValues(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectQuery("SELECT nickname FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"nickname"}).AddRow("jd").AddRow(nil))
+	mock.ExpectQuery("SELECT id, email FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}))
+
+	ids, err := SelectValues[int](db, "SELECT id FROM users")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ids)
+
+	nicknames, err := SelectValues[*string](db, "SELECT nickname FROM users")
+	require.NoError(t, err)
+	require.Len(t, nicknames, 2)
+	assert.Equal(t, "jd", *nicknames[0])
+	assert.Nil(t, nicknames[1])
+
+	_, err = SelectValues[int](db, "SELECT id, email FROM users")
+	assert.EqualError(t, err, "SelectValues expects a single column, query returned 2")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectMaps(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT team, count\\(\\*\\) AS members FROM users GROUP BY team").
+		WillReturnRows(sqlmock.NewRows([]string{"team", "members"}).
+			AddRow([]byte("red"), int64(3)).
+			AddRow(nil, int64(1)))
+	mock.ExpectQuery("SELECT email FROM users WHERE id = \\?").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"email"}))
+
+	rows, err := SelectMaps(db, "SELECT team, count(*) AS members FROM users GROUP BY team")
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]any{
+		{"team": "red", "members": int64(3)},
+		{"team": nil, "members": int64(1)},
+	}, rows)
+
+	rows, err = SelectMapsNamed(MySQL, db, "SELECT email FROM users WHERE id = :id", P{"id": 1})
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectMapsFor_DefaultOrder(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestOrderedPost]())
+	RegisterModelWithOptions[TestOrderedPost](PostgreSQL, WithDefaultOrder("created_at.desc"))
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id, title FROM test_ordered_posts ORDER BY created_at DESC").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(2, "b"))
+	mock.ExpectQuery("SELECT id, title FROM test_ordered_posts ORDER BY id").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "a"))
+
+	rows, err := SelectMapsFor[TestOrderedPost](db, "SELECT id, title FROM test_ordered_posts")
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]any{{"id": int64(2), "title": "b"}}, rows)
+
+	_, err = SelectMapsFor[TestOrderedPost](db, "SELECT id, title FROM test_ordered_posts ORDER BY id")
+	require.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestScalarQuery(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestProduct]())
+	RegisterModel[TestProduct](MySQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT MAX\\(price\\) FROM test_products").
+		WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(1299))
+	mock.ExpectQuery("SELECT SUM\\(price\\) FROM test_products WHERE name = \\?").
+		WithArgs("Widget").
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(45.5))
+
+	maxPrice, err := ScalarQuery[TestProduct, int](db, "SELECT MAX(price) FROM test_products")
+	require.NoError(t, err)
+	assert.Equal(t, 1299, maxPrice)
+
+	total, err := ScalarQueryNamed[TestProduct, float64](db, "SELECT SUM(price) FROM test_products WHERE name = :name", P{"name": "Widget"})
+	require.NoError(t, err)
+	assert.Equal(t, 45.5, total)
+
+	type UnregisteredType struct{ Id int }
+	_, err = ScalarQuery[UnregisteredType, int](db, "SELECT 1")
+	assert.ErrorAs(t, err, &NotRegisteredError{})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectPaged(t *testing.T) {
+	for _, tc := range []struct {
+		driver Driver
+		query  string
+		count  string
+		page   string
+	}{
+		{
+			PostgreSQL,
+			"SELECT * FROM test_users WHERE last_name = $1 ORDER BY id",
+			"SELECT COUNT(*) FROM (SELECT * FROM test_users WHERE last_name = $1) lit_paged",
+			"SELECT * FROM test_users WHERE last_name = $1 ORDER BY id LIMIT $2 OFFSET $3",
+		},
+		{
+			MySQL,
+			"SELECT * FROM test_users WHERE last_name = ? ORDER BY id",
+			"SELECT COUNT(*) FROM (SELECT * FROM test_users WHERE last_name = ?) lit_paged",
+			"SELECT * FROM test_users WHERE last_name = ? ORDER BY id LIMIT ? OFFSET ?",
+		},
+	} {
+		t.Run(tc.driver.Name(), func(t *testing.T) {
+			delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+			RegisterModel[TestUser](tc.driver)
+
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectQuery(tc.count).WithArgs("Doe").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(23))
+			mock.ExpectQuery(tc.page).WithArgs("Doe", 10, 20).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "first_name"}).AddRow(21, "John").AddRow(22, "Jane").AddRow(23, "Jack"))
+			mock.ExpectQuery(tc.count).WithArgs("Doe").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(23))
+			mock.ExpectQuery(tc.page).WithArgs("Doe", 10, 0).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "first_name"}).AddRow(1, "Jim"))
+
+			items, total, err := SelectPaged[TestUser](db, tc.query, 3, 10, "Doe")
+			require.NoError(t, err)
+			assert.Equal(t, int64(23), total)
+			assert.Len(t, items, 3)
+
+			items, total, err = SelectPagedNamed[TestUser](db, "SELECT * FROM test_users WHERE last_name = :name ORDER BY id", 1, 10, P{"name": "Doe"})
+			require.NoError(t, err)
+			assert.Equal(t, int64(23), total)
+			assert.Len(t, items, 1)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSelectPaged_DefaultOrder(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestOrderedPost]())
+	RegisterModelWithOptions[TestOrderedPost](PostgreSQL, WithDefaultOrder("created_at.desc, id.desc"))
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT COUNT(*) FROM (SELECT * FROM test_ordered_posts WHERE title <> $1) lit_paged").WithArgs("").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("SELECT * FROM test_ordered_posts WHERE title <> $1 ORDER BY created_at DESC,id DESC LIMIT $2 OFFSET $3").
+		WithArgs("", 2, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(3, "c").AddRow(2, "b"))
+	mock.ExpectQuery("SELECT COUNT(*) FROM (SELECT * FROM test_ordered_posts) lit_paged").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("SELECT * FROM test_ordered_posts ORDER BY title LIMIT $1 OFFSET $2").
+		WithArgs(2, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(3, "c"))
+
+	posts, total, err := SelectPaged[TestOrderedPost](db, "SELECT * FROM test_ordered_posts WHERE title <> $1", 1, 2, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Len(t, posts, 2)
+
+	// An explicit ORDER BY wins over the default order.
+	posts, _, err = SelectPaged[TestOrderedPost](db, "SELECT * FROM test_ordered_posts ORDER BY title", 2, 2)
+	require.NoError(t, err)
+	assert.Len(t, posts, 1)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectPaged_InvalidPage(t *testing.T) {
+	_, _, err := SelectPaged[TestUser](nil, "SELECT * FROM test_users", 0, 10)
+	assert.EqualError(t, err, "lit: TestUser.SelectPaged: page must be at least 1, got 0")
+
+	_, _, err = SelectPaged[TestUser](nil, "SELECT * FROM test_users", 1, 0)
+	assert.EqualError(t, err, "lit: TestUser.SelectPaged: perPage must be at least 1, got 0")
+}
+
+func TestSelectChunked(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	columns := []string{"id", "first_name", "last_name", "email"}
+	mock.ExpectQuery("SELECT id,first_name,last_name,email FROM test_users WHERE (last_name = $1) ORDER BY id ASC LIMIT $2").
+		WithArgs("Doe", 2).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "John", "Doe", "").AddRow(4, "Jane", "Doe", ""))
+	mock.ExpectQuery("SELECT id,first_name,last_name,email FROM test_users WHERE (last_name = $1) AND id > $2 ORDER BY id ASC LIMIT $3").
+		WithArgs("Doe", 4, 2).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(7, "Jack", "Doe", ""))
+
+	var chunks [][]int
+	err = SelectChunked(db, "WHERE last_name = $1", 2, func(users []*TestUser) error {
+		ids := []int{}
+		for _, u := range users {
+			ids = append(ids, u.Id)
+		}
+		chunks = append(chunks, ids)
+		return nil
+	}, "Doe")
+	require.NoError(t, err)
+	assert.Equal(t, [][]int{{1, 4}, {7}}, chunks)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectChunked_DefaultOrder(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestOrderedPost]())
+	RegisterModelWithOptions[TestOrderedPost](MySQL, WithDefaultOrder("created_at.desc"))
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	columns := []string{"id", "title", "created_at", "order"}
+	mock.ExpectQuery("SELECT id,title,created_at,`order` FROM test_ordered_posts ORDER BY created_at DESC,id ASC LIMIT ?").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(5, "e", 30, 0).AddRow(2, "b", 20, 0))
+	mock.ExpectQuery("SELECT id,title,created_at,`order` FROM test_ordered_posts WHERE (created_at < ? OR (created_at = ? AND id > ?)) ORDER BY created_at DESC,id ASC LIMIT ?").
+		WithArgs(20, 20, 2, 2).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(4, "d", 20, 0))
+
+	var ids []int
+	err = SelectChunked(db, "", 2, func(posts []*TestOrderedPost) error {
+		for _, p := range posts {
+			ids = append(ids, p.Id)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{5, 2, 4}, ids)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelectChunked_CallbackError(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](MySQL)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	columns := []string{"id", "first_name", "last_name", "email"}
+	mock.ExpectQuery("SELECT id,first_name,last_name,email FROM test_users ORDER BY id ASC LIMIT ?").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "John", "Doe", ""))
+	mock.ExpectQuery("SELECT id,first_name,last_name,email FROM test_users WHERE id > ? ORDER BY id ASC LIMIT ?").
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "Jane", "Doe", ""))
+
+	boom := errors.New("boom")
+	calls := 0
+	err = SelectChunked(db, "", 1, func(users []*TestUser) error {
+		calls++
+		if calls == 2 {
+			return boom
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, 2, calls)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	err = SelectChunked(db, "", 0, func([]*TestUser) error { return nil })
+	assert.EqualError(t, err, "lit: TestUser.SelectChunked: chunkSize must be at least 1, got 0")
+}
+
+func TestStripOrderBy(t *testing.T) {
+	for query, expected := range map[string]string{
+		"SELECT * FROM users":                                     "SELECT * FROM users",
+		"SELECT * FROM users ORDER BY id DESC":                    "SELECT * FROM users",
+		"select * from users order by id":                         "select * from users",
+		"SELECT * FROM (SELECT * FROM users ORDER BY id) u":       "SELECT * FROM (SELECT * FROM users ORDER BY id) u",
+		"SELECT * FROM users WHERE note = 'ORDER BY' ORDER BY id": "SELECT * FROM users WHERE note = 'ORDER BY'",
+	} {
+		assert.Equal(t, expected, stripOrderBy(query), query)
+	}
+}
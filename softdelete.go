@@ -0,0 +1,96 @@
+package lit
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// SoftDelete marks t as deleted by setting its softdelete column to the
+// current timestamp, identifying the row by t's id. It returns ErrNotFound
+// when no row was marked.
+func SoftDelete[T any](ex Executor, t *T) (err error) {
+	defer wrapModelError[T]("SoftDelete", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return err
+	}
+	pos, ok := fieldMap.ColumnsMap["id"]
+	if !ok {
+		return errors.New("model " + reflect.TypeFor[T]().Name() + " has no id column")
+	}
+	return softDelete(ex, fieldMap, fieldMap.field(reflect.ValueOf(t).Elem(), pos).Interface())
+}
+
+// SoftDeleteById marks the row with the given id as deleted. It returns
+// ErrNotFound when no row was marked, like DeleteById.
+func SoftDeleteById[T any](ex Executor, id any) (err error) {
+	defer wrapModelError[T]("SoftDeleteById", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return err
+	}
+	return softDelete(ex, fieldMap, id)
+}
+
+// SelectActive selects the rows of T matching where that are not soft deleted.
+// where may be empty and may start with the WHERE keyword.
+func SelectActive[T any](ex Executor, where string, args ...any) (_ []*T, err error) {
+	defer wrapModelError[T]("SelectActive", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	query, err := activeSelectQuery(ex, fieldMap, where)
+	if err != nil {
+		return nil, err
+	}
+	return Select[T](ex, query, args...)
+}
+
+// SelectSingleActive is the SelectSingle counterpart of SelectActive.
+func SelectSingleActive[T any](ex Executor, where string, args ...any) (_ *T, err error) {
+	defer wrapModelError[T]("SelectSingleActive", &err)
+	l, err := SelectActive[T](ex, where, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(l) > 0 {
+		return l[0], nil
+	}
+	return nil, nil
+}
+
+func softDelete(ex Executor, fieldMap *FieldMap, id any) error {
+	if fieldMap.SoftDeleteColumn == "" {
+		return errors.New("model has no column tagged with the softdelete option")
+	}
+	query := "UPDATE " + escapeTable(fieldMap.Driver, fieldMap.TableName) +
+		" SET " + escapeIdentifier(fieldMap.Driver, fieldMap.SoftDeleteColumn) + " = CURRENT_TIMESTAMP" +
+		" WHERE " + escapeIdentifier(fieldMap.Driver, "id") + " = " + fieldMap.Driver.Placeholder(1)
+	result, err := debugged(ex).Exec(query, id)
+	if err != nil {
+		return err
+	}
+	if rowsAffected(result) == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func activeSelectQuery(ex Executor, fieldMap *FieldMap, where string) (string, error) {
+	if fieldMap.SoftDeleteColumn == "" {
+		return "", errors.New("model has no column tagged with the softdelete option")
+	}
+
+	where = strings.TrimSpace(where)
+	if len(where) >= 6 && strings.EqualFold(where[:6], "WHERE ") {
+		where = strings.TrimSpace(where[6:])
+	}
+
+	active := escapeIdentifier(fieldMap.Driver, fieldMap.SoftDeleteColumn) + " IS NULL"
+	if where != "" {
+		active = "(" + where + ") AND " + active
+	}
+	return buildSelectQuery(ex, fieldMap, active, nil)
+}
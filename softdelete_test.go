@@ -0,0 +1,129 @@
+package lit
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type TestArchivedUser struct {
+	Id        int
+	Name      string
+	DeletedAt sql.NullTime `lit:"deleted_at,softdelete"`
+}
+
+func TestRegisterModel_SoftDelete(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestArchivedUser]())
+	RegisterModel[TestArchivedUser](PostgreSQL)
+
+	fieldMap, err := GetFieldMap(reflect.TypeFor[TestArchivedUser]())
+	require.NoError(t, err)
+	assert.Equal(t, "deleted_at", fieldMap.SoftDeleteColumn)
+}
+
+func TestSoftDelete_PostgreSQL(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestArchivedUser]())
+	RegisterModel[TestArchivedUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE test_archived_users SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1").
+		WithArgs(7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE test_archived_users SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1").
+		WithArgs(8).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, SoftDelete(db, &TestArchivedUser{Id: 7}))
+	require.NoError(t, SoftDeleteById[TestArchivedUser](db, 8))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSoftDelete_EscapesColumn_MySQL(t *testing.T) {
+	type TestRemovedItem struct {
+		Id      int
+		Removed sql.NullTime `lit:"delete,softdelete"`
+	}
+	RegisterModel[TestRemovedItem](MySQL)
+	defer delete(StructToFieldMap, reflect.TypeFor[TestRemovedItem]())
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE test_removed_items SET `delete` = CURRENT_TIMESTAMP WHERE id = ?").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT id,`delete` FROM test_removed_items WHERE `delete` IS NULL").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "delete"}))
+
+	require.NoError(t, SoftDeleteById[TestRemovedItem](db, 1))
+	_, err = SelectActive[TestRemovedItem](db, "")
+	require.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSoftDelete_NotFound(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestArchivedUser]())
+	RegisterModel[TestArchivedUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE test_archived_users SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1").
+		WithArgs(9).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = SoftDeleteById[TestArchivedUser](db, 9)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.EqualError(t, err, "lit: TestArchivedUser.SoftDeleteById: no rows found")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSoftDelete_NoSoftDeleteColumn(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+
+	err := SoftDeleteById[TestUser](nil, 1)
+	assert.Error(t, err)
+
+	_, err = SelectActive[TestUser](nil, "id = $1", 1)
+	assert.Error(t, err)
+}
+
+func TestSelectActive_SQLite(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestArchivedUser]())
+	RegisterModel[TestArchivedUser](SQLite)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT id,name,deleted_at FROM test_archived_users WHERE (name = ?) AND deleted_at IS NULL`).
+		WithArgs("John").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "deleted_at"}).AddRow(1, "John", nil))
+	mock.ExpectQuery(`SELECT id,name,deleted_at FROM test_archived_users WHERE (name = ?) AND deleted_at IS NULL`).
+		WithArgs("Jane").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "deleted_at"}))
+
+	users, err := SelectActive[TestArchivedUser](db, "name = ?", "John")
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.False(t, users[0].DeletedAt.Valid)
+
+	user, err := SelectSingleActive[TestArchivedUser](db, "WHERE name = ?", "Jane")
+	require.NoError(t, err)
+	assert.Nil(t, user)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,6 +2,7 @@ package lit
 
 import (
 	"strings"
+	"time"
 )
 
 type sqliteDriver struct{}
@@ -16,7 +17,7 @@ func (d *sqliteDriver) GenerateInsertQuery(tableName string, columnKeys []string
 	var insertQuery strings.Builder
 
 	insertQuery.WriteString("INSERT INTO ")
-	insertQuery.WriteString(sqliteEscapeReserved(tableName))
+	insertQuery.WriteString(escapeTableName(tableName, sqliteEscapeReserved))
 	insertQuery.WriteString(" (")
 
 	totalKeys := len(columnKeys)
@@ -49,7 +50,7 @@ func (d *sqliteDriver) GenerateInsertQuery(tableName string, columnKeys []string
 func (d *sqliteDriver) GenerateUpdateQuery(tableName string, columnKeys []string) string {
 	var updateQuery strings.Builder
 	updateQuery.WriteString("UPDATE ")
-	updateQuery.WriteString(sqliteEscapeReserved(tableName))
+	updateQuery.WriteString(escapeTableName(tableName, sqliteEscapeReserved))
 	updateQuery.WriteString(" SET ")
 
 	totalKeys := len(columnKeys)
@@ -67,7 +68,7 @@ func (d *sqliteDriver) GenerateUpdateQuery(tableName string, columnKeys []string
 }
 
 func (d *sqliteDriver) InsertAndGetId(ex Executor, query string, args ...any) (int, error) {
-	result, err := ex.Exec(query, args...)
+	result, err := debugged(ex).Exec(query, args...)
 	if err != nil {
 		return 0, err
 	}
@@ -92,6 +93,10 @@ func (d *sqliteDriver) JoinStringForIn(offset int, count int) string {
 	return sqliteJoinStringForIn(count)
 }
 
+func (d *sqliteDriver) ForShareClause() string { return "" }
+
+func (d *sqliteDriver) NormalizeTime(t time.Time) time.Time { return t.Round(0).UTC() }
+
 // Deprecated: Use SQLite variable directly. SqliteInsertUpdateQueryGenerator is kept for backward compatibility.
 type SqliteInsertUpdateQueryGenerator = sqliteDriver
 
@@ -0,0 +1,64 @@
+package lit
+
+import (
+	"errors"
+	"fmt"
+)
+
+// UpdateStrict is Update requiring that exactly one row was changed. It returns
+// ErrNoRowsAffected when none was and ErrTooManyRows when more were.
+//
+// MySQL reports rows whose values did not change as unaffected, so updating a
+// row to its current values fails with ErrNoRowsAffected. Add
+// clientFoundRows=true to the DSN to have MySQL report matched rows instead.
+func UpdateStrict[T any](ex Executor, t *T, where string, args ...any) (err error) {
+	defer wrapModelError[T]("UpdateStrict", &err)
+	return ExpectRows(1)(UpdateAffected(ex, t, where, args...))
+}
+
+// UpdateNamedStrict is UpdateNamed requiring exactly one changed row, see
+// UpdateStrict.
+func UpdateNamedStrict[T any](ex Executor, t *T, where string, params map[string]any, opts ...NamedOption) (err error) {
+	defer wrapModelError[T]("UpdateNamedStrict", &err)
+	return ExpectRows(1)(UpdateNamedAffected(ex, t, where, params, opts...))
+}
+
+// UpdateByIdStrict is UpdateById requiring exactly one changed row, see
+// UpdateStrict.
+func UpdateByIdStrict[T any](ex Executor, t *T) (err error) {
+	defer wrapModelError[T]("UpdateByIdStrict", &err)
+	return ExpectRows(1)(UpdateByIdAffected(ex, t))
+}
+
+// DeleteStrict is Delete requiring exactly one deleted row, see UpdateStrict.
+func DeleteStrict(ex Executor, query string, args ...any) error {
+	return ExpectRows(1)(DeleteAffected(ex, query, args...))
+}
+
+// DeleteNamedStrict is DeleteNamed requiring exactly one deleted row, see
+// UpdateStrict.
+func DeleteNamedStrict(driver Driver, ex Executor, query string, params map[string]any, opts ...NamedOption) error {
+	return ExpectRows(1)(DeleteNamedAffected(driver, ex, query, params, opts...))
+}
+
+// ExpectRows returns a check for the result of any *Affected function that
+// fails unless exactly n rows were affected:
+//
+//	err := lit.ExpectRows(3)(lit.DeleteAffected(db, "DELETE FROM users WHERE team_id = $1", teamId))
+func ExpectRows(n int64) func(affected int64, err error) error {
+	return func(affected int64, err error) error {
+		switch {
+		case err != nil:
+			return err
+		case affected < 0:
+			return errors.New("lit: the driver cannot report affected rows")
+		case affected == 0:
+			return ErrNoRowsAffected
+		case affected < n:
+			return fmt.Errorf("%w: expected %d, got %d", ErrNoRowsAffected, n, affected)
+		case affected > n:
+			return fmt.Errorf("%w: expected %d, got %d", ErrTooManyRows, n, affected)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,61 @@
+package lit
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateStrict(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE test_users SET").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE test_users SET").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("UPDATE test_users SET").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("UPDATE test_users SET").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	user := &TestUser{Id: 1, FirstName: "John"}
+	assert.NoError(t, UpdateStrict(db, user, "id = $1", 1))
+	assert.ErrorIs(t, UpdateStrict(db, user, "id = $1", 1), ErrNoRowsAffected)
+	assert.ErrorIs(t, UpdateNamedStrict(db, user, "first_name = :name", P{"name": "John"}), ErrTooManyRows)
+	assert.ErrorIs(t, UpdateByIdStrict(db, user), ErrNoRowsAffected)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteStrict(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("DELETE FROM users").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM users").WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM users").WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, DeleteStrict(db, "DELETE FROM users WHERE id = $1", 1))
+	assert.ErrorIs(t, DeleteStrict(db, "DELETE FROM users WHERE id = $1", 2), ErrNoRowsAffected)
+	assert.NoError(t, DeleteNamedStrict(PostgreSQL, db, "DELETE FROM users WHERE id = :id", P{"id": 3}))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExpectRows(t *testing.T) {
+	assert.NoError(t, ExpectRows(3)(3, nil))
+	assert.ErrorIs(t, ExpectRows(3)(0, nil), ErrNoRowsAffected)
+	assert.ErrorIs(t, ExpectRows(3)(2, nil), ErrNoRowsAffected)
+	assert.ErrorIs(t, ExpectRows(3)(4, nil), ErrTooManyRows)
+	assert.EqualError(t, ExpectRows(3)(4, nil), "lit: too many rows affected: expected 3, got 4")
+	assert.Error(t, ExpectRows(1)(-1, nil))
+
+	failed := errors.New("connection reset")
+	assert.Equal(t, failed, ExpectRows(1)(0, failed))
+}
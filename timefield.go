@@ -0,0 +1,121 @@
+package lit
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// timeField wraps a time.Time field so values pass through the driver's
+// NormalizeTime on the way in and out.
+type timeField struct {
+	field  reflect.Value
+	driver Driver
+}
+
+func (f timeField) Value() (driver.Value, error) {
+	return f.driver.NormalizeTime(f.field.Interface().(time.Time)), nil
+}
+
+func (f timeField) Scan(src any) error {
+	var t time.Time
+	switch v := src.(type) {
+	case nil:
+	case time.Time:
+		t = v
+	case []byte:
+		parsed, err := parseTimeText(string(v))
+		if err != nil {
+			return err
+		}
+		t = parsed
+	case string:
+		parsed, err := parseTimeText(v)
+		if err != nil {
+			return err
+		}
+		t = parsed
+	default:
+		return fmt.Errorf("cannot scan %T into time.Time", src)
+	}
+	if !t.IsZero() {
+		t = f.driver.NormalizeTime(t)
+	}
+	f.field.Set(reflect.ValueOf(t))
+	return nil
+}
+
+// Layouts tried when a driver returns a datetime as text.
+var timeTextLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+func parseTimeText(text string) (time.Time, error) {
+	if strings.HasPrefix(text, "0000-00-00") {
+		return time.Time{}, nil
+	}
+	for _, layout := range timeTextLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as time.Time", text)
+}
+
+// timeStringScanner stores a time.Time field as a string column formatted with
+// the layout from `lit:"...,time_format=2006-01-02"`. The zero time is stored
+// as NULL.
+type timeStringScanner struct {
+	field  reflect.Value
+	layout string
+}
+
+func (f timeStringScanner) Value() (driver.Value, error) {
+	t := f.field.Interface().(time.Time)
+	if t.IsZero() {
+		return nil, nil
+	}
+	return t.Format(f.layout), nil
+}
+
+func (f timeStringScanner) Scan(src any) error {
+	var text string
+	switch v := src.(type) {
+	case nil:
+		f.field.SetZero()
+		return nil
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	case time.Time:
+		f.field.Set(reflect.ValueOf(v))
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into time.Time", src)
+	}
+	t, err := time.Parse(f.layout, text)
+	if err != nil {
+		return err
+	}
+	f.field.Set(reflect.ValueOf(t))
+	return nil
+}
+
+// parseTimeFormat returns the layout of a time_format option, accepting both
+// time_format=layout and time_format:layout.
+func parseTimeFormat(options []string) string {
+	for _, opt := range options {
+		for _, prefix := range []string{"time_format=", "time_format:"} {
+			if layout, ok := strings.CutPrefix(opt, prefix); ok {
+				return layout
+			}
+		}
+	}
+	return ""
+}
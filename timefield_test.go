@@ -0,0 +1,96 @@
+package lit
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type TestEvent struct {
+	Id       int
+	StartsAt time.Time
+	Day      time.Time `lit:"day,time_format=2006-01-02"`
+}
+
+func TestDriverNormalizeTime(t *testing.T) {
+	berlin := time.FixedZone("CET", 3600)
+	local := time.Date(2024, 3, 1, 13, 30, 0, 123456789, berlin)
+
+	for _, d := range []Driver{PostgreSQL, CockroachDB, SQLite} {
+		normalized := d.NormalizeTime(local)
+		assert.Equal(t, time.UTC, normalized.Location(), d.Name())
+		assert.True(t, normalized.Equal(local), d.Name())
+	}
+
+	assert.Equal(t, time.Date(2024, 3, 1, 12, 30, 0, 123456000, time.UTC), MySQL.NormalizeTime(local))
+	assert.True(t, MySQL.NormalizeTime(time.Time{}).IsZero())
+
+	// time.Now carries a monotonic clock reading, which breaks == comparisons.
+	now := time.Now()
+	assert.Equal(t, now.Round(0).UTC(), PostgreSQL.NormalizeTime(now))
+}
+
+func TestRegisterModel_TimeFields(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestEvent]())
+	RegisterModel[TestEvent](PostgreSQL)
+
+	fieldMap, err := GetFieldMap(reflect.TypeFor[TestEvent]())
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, fieldMap.TimeFields)
+	assert.Equal(t, map[int]string{2: "2006-01-02"}, fieldMap.TimeFormats)
+}
+
+func TestRegisterModel_TimeFormatColonSyntax(t *testing.T) {
+	type TestBirthday struct {
+		Id  int
+		Day time.Time `lit:"day,time_format:2006-01-02"`
+	}
+	RegisterModel[TestBirthday](PostgreSQL)
+
+	fieldMap, err := GetFieldMap(reflect.TypeFor[TestBirthday]())
+	require.NoError(t, err)
+	assert.Equal(t, map[int]string{1: "2006-01-02"}, fieldMap.TimeFormats)
+}
+
+func TestRegisterModel_TimeFormatRequiresTime(t *testing.T) {
+	type BadTimeFormat struct {
+		Id  int
+		Day string `lit:"day,time_format=2006-01-02"`
+	}
+	assert.Panics(t, func() { RegisterModel[BadTimeFormat](PostgreSQL) })
+}
+
+func TestTimeFields_RoundTrip(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestEvent]())
+	RegisterModel[TestEvent](MySQL)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	startsAt := time.Date(2024, 3, 1, 13, 30, 0, 999, time.FixedZone("CET", 3600))
+	mock.ExpectExec("INSERT INTO test_events").
+		WithArgs(time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC), "2024-03-01").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "starts_at", "day"}).
+			AddRow(1, []byte("0000-00-00 00:00:00"), []byte("2024-03-01")).
+			AddRow(2, "2024-03-01 12:30:00", nil))
+
+	_, err = Insert(db, &TestEvent{StartsAt: startsAt, Day: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)})
+	require.NoError(t, err)
+
+	events, err := Select[TestEvent](db, "SELECT * FROM test_events")
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.True(t, events[0].StartsAt.IsZero())
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), events[0].Day)
+	assert.Equal(t, time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC), events[1].StartsAt)
+	assert.True(t, events[1].Day.IsZero())
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
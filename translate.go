@@ -0,0 +1,232 @@
+package lit
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// TranslatePlaceholders rewrites the ? placeholders of query to driver's, so
+// queries written for MySQL or SQLite run unchanged on PostgreSQL:
+//
+//	lit.TranslatePlaceholders(lit.PostgreSQL, "SELECT * FROM users WHERE id = ? AND status = ?")
+//	// SELECT * FROM users WHERE id = $1 AND status = $2
+//
+// String literals, quoted identifiers and comments are left alone, as are the
+// ?| and ?& JSON operators; write ?? for a literal ? operator. For drivers
+// that use ? themselves query is returned unchanged.
+func TranslatePlaceholders(driver Driver, query string) string {
+	if driver.Placeholder(1) == driver.Placeholder(2) || !strings.Contains(query, "?") {
+		return query
+	}
+
+	runes := []rune(query)
+	lexer := newSQLLexer(driver)
+	var out strings.Builder
+	n := 0
+	for i := 0; i < len(runes); i++ {
+		if end := lexer.skip(runes, i); end != -1 {
+			out.WriteString(string(runes[i:end]))
+			i = end - 1
+			continue
+		}
+
+		r := runes[i]
+		if r != '?' {
+			out.WriteRune(r)
+			continue
+		}
+		if i+1 < len(runes) && runes[i+1] == '?' {
+			out.WriteRune('?')
+			i++
+			continue
+		}
+		if i+1 < len(runes) && (runes[i+1] == '|' || runes[i+1] == '&') {
+			out.WriteRune(r)
+			continue
+		}
+		n++
+		out.WriteString(driver.Placeholder(n))
+	}
+	return out.String()
+}
+
+// SelectQ is Select for a query written with ? placeholders, see
+// TranslatePlaceholders.
+func SelectQ[T any](ex Executor, query string, args ...any) (_ []*T, err error) {
+	defer wrapModelError[T]("SelectQ", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	return Select[T](ex, TranslatePlaceholders(fieldMap.Driver, query), args...)
+}
+
+// SelectSingleQ is SelectSingle for a query written with ? placeholders, see
+// TranslatePlaceholders.
+func SelectSingleQ[T any](ex Executor, query string, args ...any) (_ *T, err error) {
+	defer wrapModelError[T]("SelectSingleQ", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	return SelectSingle[T](ex, TranslatePlaceholders(fieldMap.Driver, query), args...)
+}
+
+// UpdateQ is Update for a where clause written with ? placeholders, see
+// TranslatePlaceholders.
+func UpdateQ[T any](ex Executor, t *T, where string, args ...any) (err error) {
+	defer wrapModelError[T]("UpdateQ", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return err
+	}
+	return Update(ex, t, TranslatePlaceholders(fieldMap.Driver, where), args...)
+}
+
+// DeleteQ is Delete for a query written with ? placeholders, see
+// TranslatePlaceholders.
+func DeleteQ(driver Driver, ex Executor, query string, args ...any) error {
+	return Delete(ex, TranslatePlaceholders(driver, query), args...)
+}
+
+// TranslateDollarPlaceholders rewrites the $N placeholders of a query written
+// for PostgreSQL to ? for drivers that number their placeholders by position,
+// e.g. MySQL and SQLite. Since $N can appear out of order or repeat, it also
+// returns a func arranging the args for the rewritten query:
+//
+//	query, reorder, err := lit.TranslateDollarPlaceholders(lit.MySQL, "SELECT * FROM users WHERE email = $2 OR backup_email = $2 AND id <> $1")
+//	// SELECT * FROM users WHERE email = ? OR backup_email = ? AND id <> ?
+//	rows, err := db.Query(query, reorder([]any{id, email})...) // email, email, id
+//
+// String literals, comments and dollar-quoted strings are left alone. For
+// drivers with numbered placeholders query and args are returned unchanged.
+func TranslateDollarPlaceholders(driver Driver, query string) (string, func(args []any) []any, error) {
+	translated, order, err := translateDollarPlaceholders(driver, query)
+	if err != nil {
+		return "", nil, err
+	}
+	return translated, func(args []any) []any { return reorderArgs(order, args) }, nil
+}
+
+func translateDollarPlaceholders(driver Driver, query string) (string, []int, error) {
+	if driver.Placeholder(1) != driver.Placeholder(2) || !strings.Contains(query, "$") {
+		return query, nil, nil
+	}
+
+	runes := []rune(query)
+	lexer := newSQLLexer(driver)
+	lexer.postgres = true // the query is written for PostgreSQL
+	var out strings.Builder
+	order := []int{}
+	for i := 0; i < len(runes); i++ {
+		if end := lexer.skip(runes, i); end != -1 {
+			out.WriteString(string(runes[i:end]))
+			i = end - 1
+			continue
+		}
+
+		r := runes[i]
+		if r != '$' || i+1 >= len(runes) || !unicode.IsDigit(runes[i+1]) || (i > 0 && isParamChar(runes[i-1])) {
+			out.WriteRune(r)
+			continue
+		}
+		j := i + 1
+		for j < len(runes) && unicode.IsDigit(runes[j]) {
+			j++
+		}
+		n, err := strconv.Atoi(string(runes[i+1 : j]))
+		if err != nil || n < 1 {
+			return "", nil, fmt.Errorf("invalid placeholder %s", string(runes[i:j]))
+		}
+		order = append(order, n)
+		out.WriteString(driver.Placeholder(len(order)))
+		i = j - 1
+	}
+	return out.String(), order, nil
+}
+
+// reorderArgs returns the args in the order the placeholders used them. A nil
+// order, from a query that needed no translation, keeps args as they are.
+func reorderArgs(order []int, args []any) []any {
+	if order == nil {
+		return args
+	}
+	reordered := make([]any, len(order))
+	for i, n := range order {
+		if n <= len(args) {
+			reordered[i] = args[n-1]
+		}
+	}
+	return reordered
+}
+
+func translateDollarArgs(driver Driver, query string, args []any) (string, []any, error) {
+	translated, order, err := translateDollarPlaceholders(driver, query)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, n := range order {
+		if n > len(args) {
+			return "", nil, fmt.Errorf("query uses $%d but only %d args were given", n, len(args))
+		}
+	}
+	return translated, reorderArgs(order, args), nil
+}
+
+// SelectT is Select for a query written with PostgreSQL's $N placeholders,
+// see TranslateDollarPlaceholders.
+func SelectT[T any](ex Executor, query string, args ...any) (_ []*T, err error) {
+	defer wrapModelError[T]("SelectT", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	translated, args, err := translateDollarArgs(fieldMap.Driver, query, args)
+	if err != nil {
+		return nil, err
+	}
+	return Select[T](ex, translated, args...)
+}
+
+// SelectSingleT is SelectSingle for a query written with PostgreSQL's $N
+// placeholders, see TranslateDollarPlaceholders.
+func SelectSingleT[T any](ex Executor, query string, args ...any) (_ *T, err error) {
+	defer wrapModelError[T]("SelectSingleT", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	translated, args, err := translateDollarArgs(fieldMap.Driver, query, args)
+	if err != nil {
+		return nil, err
+	}
+	return SelectSingle[T](ex, translated, args...)
+}
+
+// UpdateT is Update for a where clause written with PostgreSQL's $N
+// placeholders, see TranslateDollarPlaceholders.
+func UpdateT[T any](ex Executor, t *T, where string, args ...any) (err error) {
+	defer wrapModelError[T]("UpdateT", &err)
+	fieldMap, err := GetFieldMap(reflect.TypeFor[T]())
+	if err != nil {
+		return err
+	}
+	translated, args, err := translateDollarArgs(fieldMap.Driver, where, args)
+	if err != nil {
+		return err
+	}
+	return Update(ex, t, translated, args...)
+}
+
+// DeleteT is Delete for a query written with PostgreSQL's $N placeholders, see
+// TranslateDollarPlaceholders.
+func DeleteT(driver Driver, ex Executor, query string, args ...any) error {
+	translated, args, err := translateDollarArgs(driver, query, args)
+	if err != nil {
+		return err
+	}
+	return Delete(ex, translated, args...)
+}
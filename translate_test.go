@@ -0,0 +1,130 @@
+package lit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslatePlaceholders(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{"placeholders", "SELECT * FROM users WHERE id = ? AND status = ?", "SELECT * FROM users WHERE id = $1 AND status = $2"},
+		{"string literals", "SELECT 'why?', \"who?\" FROM users WHERE id = ?", "SELECT 'why?', \"who?\" FROM users WHERE id = $1"},
+		{"escaped quote", "SELECT 'it''s ?' WHERE id = ?", "SELECT 'it''s ?' WHERE id = $1"},
+		{"comments", "SELECT 1 -- id = ?\nWHERE id = ? /* or ? */", "SELECT 1 -- id = ?\nWHERE id = $1 /* or ? */"},
+		{"escaped operator", "SELECT * FROM docs WHERE data ?? 'key' AND id = ?", "SELECT * FROM docs WHERE data ? 'key' AND id = $1"},
+		{"JSON operators", "SELECT * FROM docs WHERE data ?| array['a'] AND data ?& array['b'] AND id = ?", "SELECT * FROM docs WHERE data ?| array['a'] AND data ?& array['b'] AND id = $1"},
+		{"dollar quotes", "SELECT $$why?$$ WHERE id = ?", "SELECT $$why?$$ WHERE id = $1"},
+		{"tagged dollar quotes", "SELECT $fn$ a ? $$ b ? $fn$ WHERE id = ?", "SELECT $fn$ a ? $$ b ? $fn$ WHERE id = $1"},
+		{"nested comments", "SELECT 1 /* a /* ? */ ? */ WHERE id = ?", "SELECT 1 /* a /* ? */ ? */ WHERE id = $1"},
+		{"no placeholders", "SELECT 1", "SELECT 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, TranslatePlaceholders(PostgreSQL, tt.query))
+		})
+	}
+
+	query := "SELECT * FROM users WHERE id = ? AND data ?? 'x'"
+	assert.Equal(t, query, TranslatePlaceholders(MySQL, query))
+	assert.Equal(t, query, TranslatePlaceholders(SQLite, query))
+	assert.Equal(t, "SELECT * FROM users WHERE id = $1", TranslatePlaceholders(CockroachDB, "SELECT * FROM users WHERE id = ?"))
+}
+
+func TestSelectQ(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](PostgreSQL)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT * FROM test_users WHERE last_name = $1 AND email <> $2").
+		WithArgs("Doe", "").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "email"}).
+			AddRow(1, "John", "Doe", "john@example.com"))
+
+	users, err := SelectQ[TestUser](db, "SELECT * FROM test_users WHERE last_name = ? AND email <> ?", "Doe", "")
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "John", users[0].FirstName)
+
+	mock.ExpectExec("UPDATE test_users SET id = $1,first_name = $2,last_name = $3,email = $4 WHERE id = $5").
+		WithArgs(1, "Jane", "Doe", "jane@example.com", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, UpdateQ(db, &TestUser{Id: 1, FirstName: "Jane", LastName: "Doe", Email: "jane@example.com"}, "id = ?", 1))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTranslateDollarPlaceholders(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+		args     []any
+	}{
+		{"in order", "SELECT * FROM users WHERE id = $1 AND status = $2", "SELECT * FROM users WHERE id = ? AND status = ?", []any{"id", "status"}},
+		{"out of order", "SELECT * FROM users WHERE status = $2 AND id = $1", "SELECT * FROM users WHERE status = ? AND id = ?", []any{"status", "id"}},
+		{"repeated", "SELECT * FROM users WHERE email = $2 OR backup_email = $2 AND id <> $1", "SELECT * FROM users WHERE email = ? OR backup_email = ? AND id <> ?", []any{"status", "status", "id"}},
+		{"string literals", "SELECT '$1', \"$2\" FROM users WHERE id = $1", "SELECT '$1', \"$2\" FROM users WHERE id = ?", []any{"id"}},
+		{"dollar quotes", "SELECT $$costs $2$$, $tag$ $1 $tag$ WHERE id = $1", "SELECT $$costs $2$$, $tag$ $1 $tag$ WHERE id = ?", []any{"id"}},
+		{"comments", "SELECT 1 -- $2\nWHERE id = $1", "SELECT 1 -- $2\nWHERE id = ?", []any{"id"}},
+		{"identifiers", "SELECT price$1 FROM items WHERE id = $1", "SELECT price$1 FROM items WHERE id = ?", []any{"id"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, reorder, err := TranslateDollarPlaceholders(MySQL, tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, query)
+			assert.Equal(t, tt.args, reorder([]any{"id", "status"}))
+		})
+	}
+
+	query := "SELECT * FROM users WHERE status = $2 AND id = $1"
+	translated, reorder, err := TranslateDollarPlaceholders(PostgreSQL, query)
+	require.NoError(t, err)
+	assert.Equal(t, query, translated)
+	assert.Equal(t, []any{1, 2}, reorder([]any{1, 2}))
+
+	_, _, err = TranslateDollarPlaceholders(SQLite, "SELECT * FROM users WHERE id = $0")
+	assert.EqualError(t, err, "invalid placeholder $0")
+}
+
+func TestSelectT(t *testing.T) {
+	delete(StructToFieldMap, reflect.TypeFor[TestUser]())
+	RegisterModel[TestUser](MySQL)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT * FROM test_users WHERE last_name = ? AND id > ? AND first_name <> ?").
+		WithArgs("Doe", 10, "Doe").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "email"}).
+			AddRow(11, "John", "Doe", "john@example.com"))
+
+	users, err := SelectT[TestUser](db, "SELECT * FROM test_users WHERE last_name = $1 AND id > $2 AND first_name <> $1", "Doe", 10)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "John", users[0].FirstName)
+
+	_, err = SelectT[TestUser](db, "SELECT * FROM test_users WHERE id = $3", 1)
+	assert.EqualError(t, err, "lit: TestUser.SelectT: query uses $3 but only 1 args were given")
+
+	mock.ExpectExec("DELETE FROM test_users WHERE id = ?").
+		WithArgs(11).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, DeleteT(MySQL, db, "DELETE FROM test_users WHERE id = $1", 11))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,73 @@
+package lit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// TxStarter begins transactions; *sql.DB and *sql.Conn implement it.
+type TxStarter interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// TxOptions are the isolation level and read-only mode of a transaction, see
+// WithTransactionOptions. The zero value uses the database's defaults.
+type TxOptions struct {
+	Isolation sql.IsolationLevel
+	ReadOnly  bool
+}
+
+// ReadOnly returns options for a read-only transaction at the default
+// isolation level.
+func ReadOnly() TxOptions {
+	return TxOptions{ReadOnly: true}
+}
+
+// ReadCommitted returns options for a READ COMMITTED transaction.
+func ReadCommitted() TxOptions {
+	return TxOptions{Isolation: sql.LevelReadCommitted}
+}
+
+// RepeatableRead returns options for a REPEATABLE READ transaction.
+func RepeatableRead() TxOptions {
+	return TxOptions{Isolation: sql.LevelRepeatableRead}
+}
+
+// Serializable returns options for a SERIALIZABLE transaction.
+func Serializable() TxOptions {
+	return TxOptions{Isolation: sql.LevelSerializable}
+}
+
+// WithTransaction runs fn in a transaction that is committed when fn returns
+// nil and rolled back when it returns an error or panics.
+func WithTransaction(db TxStarter, fn func(Executor) error) error {
+	return WithTransactionOptions(db, TxOptions{}, fn)
+}
+
+// WithTransactionOptions is WithTransaction with the given isolation level and
+// read-only mode:
+//
+//	err := lit.WithTransactionOptions(db, lit.Serializable(), func(tx lit.Executor) error {
+//		...
+//	})
+func WithTransactionOptions(db TxStarter, opts TxOptions, fn func(Executor) error) (err error) {
+	tx, err := db.BeginTx(context.Background(), &sql.TxOptions{Isolation: opts.Isolation, ReadOnly: opts.ReadOnly})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rollbackErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
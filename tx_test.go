@@ -0,0 +1,57 @@
+package lit
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxOptions(t *testing.T) {
+	assert.Equal(t, TxOptions{ReadOnly: true}, ReadOnly())
+	assert.Equal(t, TxOptions{Isolation: sql.LevelReadCommitted}, ReadCommitted())
+	assert.Equal(t, TxOptions{Isolation: sql.LevelRepeatableRead}, RepeatableRead())
+	assert.Equal(t, TxOptions{Isolation: sql.LevelSerializable}, Serializable())
+}
+
+func TestWithTransactionOptions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM sessions").WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	err = WithTransactionOptions(db, Serializable(), func(tx Executor) error {
+		return Delete(tx, "DELETE FROM sessions")
+	})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTransaction_Rollback(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	failed := errors.New("failed")
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	err = WithTransaction(db, func(tx Executor) error {
+		return failed
+	})
+	assert.ErrorIs(t, err, failed)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = WithTransaction(db, func(tx Executor) error {
+			panic("boom")
+		})
+	})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
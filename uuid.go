@@ -0,0 +1,54 @@
+package lit
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
+
+// UUIDVersion selects the generator used by InsertUuid.
+type UUIDVersion int
+
+const (
+	// UUIDv7 is time-ordered and index friendly.
+	UUIDv7 UUIDVersion = iota
+	// UUIDv4 is fully random, as from uuid.New(). This is the default.
+	UUIDv4
+	// UUIDv1 is time and node (MAC) based.
+	UUIDv1
+)
+
+var uuidVersion = UUIDv4
+
+var uuidType = reflect.TypeFor[uuid.UUID]()
+
+// SetUuidVersion sets the UUID version InsertUuid generates.
+func SetUuidVersion(version UUIDVersion) {
+	uuidVersion = version
+}
+
+// customUuidGenerator holds the func() (string, error) set by SetUuidGenerator.
+var customUuidGenerator atomic.Value
+
+// SetUuidGenerator replaces the UUIDs InsertUuid (and InsertWithGenerator
+// without a generator) assigns with the ids fn returns, e.g. ULIDs, KSUIDs or
+// shard-friendly sequential UUIDs. The ids must parse as UUIDs when the id
+// field is a uuid.UUID. A nil fn restores the generator picked by
+// SetUuidVersion.
+func SetUuidGenerator(fn func() (string, error)) {
+	customUuidGenerator.Store(fn)
+}
+
+func generateUuid() (uuid.UUID, error) {
+	switch uuidVersion {
+	case UUIDv7:
+		return uuid.NewV7()
+	case UUIDv4:
+		return uuid.NewRandom()
+	case UUIDv1:
+		return uuid.NewUUID()
+	}
+	return uuid.Nil, fmt.Errorf("unknown uuid version %d", uuidVersion)
+}
@@ -0,0 +1,80 @@
+package lit
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetUuidVersion(t *testing.T) {
+	defer SetUuidVersion(UUIDv4)
+	RegisterModel[TestProduct](PostgreSQL)
+
+	for _, tc := range []struct {
+		version  UUIDVersion
+		expected uuid.Version
+	}{
+		{UUIDv7, 7},
+		{UUIDv4, 4},
+		{UUIDv1, 1},
+	} {
+		SetUuidVersion(tc.version)
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectExec("INSERT INTO test_products").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		id, err := InsertUuid(db, &TestProduct{Name: "Widget"})
+		require.NoError(t, err)
+
+		parsed, err := uuid.Parse(id)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, parsed.Version())
+		db.Close()
+	}
+}
+
+func TestSetUuidVersion_Unknown(t *testing.T) {
+	defer SetUuidVersion(UUIDv4)
+	RegisterModel[TestProduct](PostgreSQL)
+	SetUuidVersion(UUIDVersion(42))
+
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = InsertUuid(db, &TestProduct{Name: "Widget"})
+	assert.EqualError(t, err, "lit: TestProduct.InsertUuid: unknown uuid version 42")
+}
+
+func TestSetUuidGenerator(t *testing.T) {
+	defer SetUuidGenerator(nil)
+	RegisterModel[TestProduct](PostgreSQL)
+	SetUuidGenerator(func() (string, error) { return "product-1", nil })
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	mock.ExpectExec("INSERT INTO test_products").
+		WithArgs("product-1", "Widget", 0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	product := &TestProduct{Name: "Widget"}
+	id, err := InsertUuid(db, product)
+	require.NoError(t, err)
+	assert.Equal(t, "product-1", id)
+	assert.Equal(t, "product-1", product.Id)
+
+	SetUuidGenerator(nil)
+	mock.ExpectExec("INSERT INTO test_products").WillReturnResult(sqlmock.NewResult(0, 1))
+	id, err = InsertUuid(db, &TestProduct{Name: "Widget"})
+	require.NoError(t, err)
+	parsed, err := uuid.Parse(id)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Version(4), parsed.Version())
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,141 @@
+package lit
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+)
+
+// ValidateRegistry checks every registered model for inconsistencies and
+// returns all problems found, or nil. When an Executor is passed, each model's
+// columns are also verified against the live schema (optional columns may be
+// missing). Call it once at startup to fail fast. lit has no tenant, version
+// or relation tags, so the tag checks cover softdelete, default, optional and
+// the id primary key.
+func ValidateRegistry(ex ...Executor) []error {
+	var errs []error
+
+	types := make([]reflect.Type, 0, len(StructToFieldMap))
+	for t := range StructToFieldMap {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].String() < types[j].String() })
+
+	tableDrivers := map[string]Driver{}
+	for _, t := range types {
+		fieldMap := StructToFieldMap[t]
+		for _, err := range validateFieldMap(fieldMap) {
+			errs = append(errs, fmt.Errorf("%s: %w", t.Name(), err))
+		}
+
+		if other, ok := tableDrivers[fieldMap.TableName]; ok && other != fieldMap.Driver {
+			errs = append(errs, fmt.Errorf("%s: table %s is registered with both %s and %s", t.Name(), fieldMap.TableName, other.Name(), fieldMap.Driver.Name()))
+		} else {
+			tableDrivers[fieldMap.TableName] = fieldMap.Driver
+		}
+
+		if len(ex) > 0 {
+			for _, err := range verifySchema(ex[0], fieldMap) {
+				errs = append(errs, fmt.Errorf("%s: %w", t.Name(), err))
+			}
+		}
+	}
+	return errs
+}
+
+func validateFieldMap(fieldMap *FieldMap) []error {
+	var errs []error
+
+	if fieldMap.Driver == nil {
+		return []error{fmt.Errorf("no driver")}
+	}
+
+	if err := checkPlaceholders(fieldMap.Driver, "insert", fieldMap.InsertQuery, len(fieldMap.InsertColumns)); err != nil {
+		errs = append(errs, err)
+	}
+	if err := checkPlaceholders(fieldMap.Driver, "update", fieldMap.UpdateQuery, len(fieldMap.UpdateColumns)); err != nil {
+		errs = append(errs, err)
+	}
+
+	referenced := map[string][]string{
+		"insert":   fieldMap.InsertColumns,
+		"update":   fieldMap.UpdateColumns,
+		"optional": fieldMap.OptionalColumns,
+		"default":  fieldMap.DefaultColumns,
+	}
+	if fieldMap.SoftDeleteColumn != "" {
+		referenced["softdelete"] = []string{fieldMap.SoftDeleteColumn}
+	}
+	kinds := make([]string, 0, len(referenced))
+	for kind := range referenced {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		for _, column := range referenced[kind] {
+			if _, ok := fieldMap.ColumnsMap[column]; !ok {
+				errs = append(errs, fmt.Errorf("%s column %s is not found in the struct", kind, column))
+			}
+		}
+	}
+
+	if _, ok := fieldMap.ColumnsMap["id"]; !ok && (fieldMap.HasIntId || fieldMap.HasUuidId || fieldMap.IDGeneratorName != "") {
+		errs = append(errs, fmt.Errorf("primary key column id is not found in the struct"))
+	}
+
+	for column, pos := range fieldMap.ColumnsMap {
+		if pos < 0 || pos >= len(fieldMap.ColumnKeys) || fieldMap.ColumnKeys[pos] != column {
+			errs = append(errs, fmt.Errorf("column %s maps to field %d which does not match the column keys", column, pos))
+		}
+	}
+
+	return errs
+}
+
+// checkPlaceholders verifies that a cached query binds exactly count arguments.
+// Placeholders are found with the driver's lexer, so a ? inside a literal
+// (e.g. a default value) doesn't count and $1 doesn't match inside $10.
+func checkPlaceholders(driver Driver, kind string, query string, count int) error {
+	found := scanPlaceholders(driver, query)
+	if driver.Placeholder(1) == driver.Placeholder(2) {
+		if len(found) != count {
+			return fmt.Errorf("%s query has %d placeholders for %d columns", kind, len(found), count)
+		}
+		return nil
+	}
+	seen := make([]bool, count+1)
+	for _, p := range found {
+		if p.n < 1 || p.n > count {
+			return fmt.Errorf("%s query has placeholder %s beyond its %d columns", kind, driver.Placeholder(p.n), count)
+		}
+		seen[p.n] = true
+	}
+	for i := 1; i <= count; i++ {
+		if !seen[i] {
+			return fmt.Errorf("%s query is missing placeholder %s for %d columns", kind, driver.Placeholder(i), count)
+		}
+	}
+	return nil
+}
+
+func verifySchema(ex Executor, fieldMap *FieldMap) []error {
+	existing, err := tableColumns(ex, fieldMap.Driver, fieldMap.TableName)
+	if err != nil {
+		return []error{err}
+	}
+	if len(existing) == 0 {
+		return []error{fmt.Errorf("table %s does not exist", fieldMap.TableName)}
+	}
+
+	var errs []error
+	for _, column := range fieldMap.ColumnKeys {
+		if slices.Contains(fieldMap.OptionalColumns, column) {
+			continue
+		}
+		if !slices.Contains(existing, column) {
+			errs = append(errs, fmt.Errorf("column %s does not exist in table %s", column, fieldMap.TableName))
+		}
+	}
+	return errs
+}
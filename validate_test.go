@@ -0,0 +1,144 @@
+package lit
+
+import (
+	"database/sql/driver"
+	"errors"
+	"maps"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withEmptyRegistry runs fn against an empty model registry, restoring the
+// original one afterwards.
+func withEmptyRegistry(t *testing.T, fn func()) {
+	original := StructToFieldMap
+	StructToFieldMap = make(map[reflect.Type]*FieldMap)
+	defer func() { StructToFieldMap = original }()
+	fn()
+}
+
+func TestValidateRegistry_Valid(t *testing.T) {
+	withEmptyRegistry(t, func() {
+		RegisterModel[TestUser](PostgreSQL)
+		RegisterModel[TestProduct](MySQL)
+		RegisterModel[TestIndexedDoc](SQLite)
+		RegisterModel[TestArchivedUser](CockroachDB)
+
+		assert.Empty(t, ValidateRegistry())
+	})
+}
+
+func TestValidateRegistry_BrokenRegistrations(t *testing.T) {
+	withEmptyRegistry(t, func() {
+		RegisterModel[TestUser](PostgreSQL)
+		fieldMap := StructToFieldMap[reflect.TypeFor[TestUser]()]
+		fieldMap.InsertQuery = "INSERT INTO test_users (first_name) VALUES ($1)"
+		fieldMap.SoftDeleteColumn = "deleted_at"
+
+		RegisterModel[TestProduct](MySQL)
+		StructToFieldMap[reflect.TypeFor[TestProduct]()].UpdateQuery = "UPDATE test_products SET id = ? WHERE "
+
+		type TestUserCopy struct {
+			Id   int
+			Name string
+		}
+		RegisterModelWithNaming[TestUserCopy](SQLite, fixedTableNaming{"test_users"})
+
+		errs := ValidateRegistry()
+		require.Len(t, errs, 4)
+		assert.Contains(t, errs[0].Error(), "TestProduct: update query has 1 placeholders for 3 columns")
+		assert.Contains(t, errs[1].Error(), "TestUser: insert query is missing placeholder $2 for 3 columns")
+		assert.Contains(t, errs[2].Error(), "TestUser: softdelete column deleted_at is not found in the struct")
+		assert.Contains(t, errs[3].Error(), "TestUserCopy: table test_users is registered with both PostgreSQL and SQLite")
+	})
+}
+
+func TestCheckPlaceholders(t *testing.T) {
+	assert.NoError(t, checkPlaceholders(MySQL, "insert", "INSERT INTO t (a,b) VALUES (?,COALESCE(?, 'why?')) -- or ?", 2))
+	assert.EqualError(t, checkPlaceholders(MySQL, "insert", "INSERT INTO t (a,b) VALUES (?,'?')", 2), "insert query has 1 placeholders for 2 columns")
+
+	assert.NoError(t, checkPlaceholders(PostgreSQL, "insert", "INSERT INTO t (a,b) VALUES ($1,$2) RETURNING id", 2))
+	assert.NoError(t, checkPlaceholders(CockroachDB, "insert", "INSERT INTO t (id,a) VALUES (COALESCE(NULLIF(NULLIF($1, ''), '$3')::UUID, gen_random_uuid()),$2)", 2))
+	assert.EqualError(t, checkPlaceholders(PostgreSQL, "update", "UPDATE t SET a = $10 ", 1), "update query has placeholder $10 beyond its 1 columns")
+	assert.EqualError(t, checkPlaceholders(PostgreSQL, "update", "UPDATE t SET a = $1, b = '$2' ", 2), "update query is missing placeholder $2 for 2 columns")
+}
+
+func TestValidateRegistry_MissingPrimaryKey(t *testing.T) {
+	withEmptyRegistry(t, func() {
+		RegisterModel[TestUser](PostgreSQL)
+		fieldMap := StructToFieldMap[reflect.TypeFor[TestUser]()]
+		fieldMap.ColumnsMap = maps.Clone(fieldMap.ColumnsMap)
+		delete(fieldMap.ColumnsMap, "id")
+
+		assert.Contains(t, errors.Join(ValidateRegistry()...).Error(), "TestUser: primary key column id is not found in the struct")
+	})
+}
+
+func TestValidateRegistry_VerifySchema(t *testing.T) {
+	withEmptyRegistry(t, func() {
+		RegisterModelWithOptions[TestFlaggedUser](PostgreSQL, WithOptionalColumns("new_flag"))
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT column_name FROM information_schema.columns").
+			WithArgs("test_flagged_users").
+			WillReturnRows(sqlmock.NewRows([]string{"column_name"}).AddRow("id"))
+
+		errs := ValidateRegistry(db)
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0].Error(), "column name does not exist in table test_flagged_users")
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+type TestAuditEntry struct {
+	Id     int
+	Action string
+}
+
+func TestValidateRegistry_VerifySchema_SchemaQualified(t *testing.T) {
+	cases := []struct {
+		driver Driver
+		probe  string
+	}{
+		{PostgreSQL, "SELECT column_name FROM information_schema.columns WHERE table_schema = $1 AND table_name = $2"},
+		{MySQL, "SELECT column_name FROM information_schema.columns WHERE table_schema = ? AND table_name = ?"},
+		{SQLite, "SELECT name FROM pragma_table_info(?, ?)"},
+	}
+	for _, c := range cases {
+		withEmptyRegistry(t, func() {
+			RegisterModelWithNaming[TestAuditEntry](c.driver, NewSchemaStrategy("audit", DefaultDbNamingStrategy{}))
+
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+			defer db.Close()
+
+			args := []driver.Value{"audit", "test_audit_entrys"}
+			if c.driver == SQLite {
+				args = []driver.Value{"test_audit_entrys", "audit"}
+			}
+			mock.ExpectQuery(c.probe).WithArgs(args...).
+				WillReturnRows(sqlmock.NewRows([]string{"column_name"}).AddRow("id").AddRow("action"))
+
+			assert.Empty(t, ValidateRegistry(db), c.driver.Name())
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+type fixedTableNaming struct {
+	table string
+}
+
+func (f fixedTableNaming) GetTableNameFromStructName(string) string { return f.table }
+
+func (f fixedTableNaming) GetColumnNameFromStructName(input string) string {
+	return toSnakeCase(input)
+}